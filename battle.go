@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/smwalke83/pokedex/internal/battle"
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/session"
+	"github.com/smwalke83/pokedex/internal/stats"
+	"github.com/smwalke83/pokedex/internal/typechart"
+)
+
+const maxBattleMoves = 4
+
+// prizePerLevel is the money won for beating a wild pokemon, per level.
+const prizePerLevel = 20
+
+func commandBattle(ctx context.Context, c *session.Session, args []string, flags map[string]string) error {
+	mine, err := c.FindOwned(args[0])
+	if err != nil {
+		return err
+	}
+	var rng battle.RNG = c.RNG
+	if flags["seed"] != "" {
+		seed, err := strconv.ParseInt(flags["seed"], 10, 64)
+		if err != nil {
+			return fmt.Errorf("Invalid command - %q is not a seed.", flags["seed"])
+		}
+		rng = rand.New(rand.NewSource(seed))
+	}
+	level := mine.CurrentLevel()
+	// The opponent is one of your own pokemon when given by ID, and a wild
+	// one otherwise.
+	var theirs session.CaughtPokemon
+	_, err = strconv.Atoi(strings.TrimPrefix(args[1], "#"))
+	owned := err == nil
+	if owned {
+		theirs, err = c.FindOwned(args[1])
+		if err != nil {
+			return err
+		}
+	} else {
+		name, err := c.ResolveName(ctx, "pokemon", args[1])
+		if err != nil {
+			return err
+		}
+		poke, err := c.Client.GetPokemon(ctx, name)
+		if err != nil {
+			return err
+		}
+		// A wild pokemon fights at the level it was found at, or at the
+		// same level as yours.
+		theirs = session.CaughtPokemon{
+			Pokemon:    poke,
+			Level:      level,
+			NatureName: stats.RandomNature(rng).Name,
+			IVs:        stats.RandomIVs(rng),
+		}
+		if c.Wild != nil && c.Wild.Name == poke.Name {
+			theirs.Level = c.Wild.Level
+		}
+	}
+	chart, err := c.TypeChart(ctx, 0)
+	if err != nil {
+		return err
+	}
+	a, err := combatant(ctx, c, mine)
+	if err != nil {
+		return err
+	}
+	b, err := combatant(ctx, c, theirs)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s (Lv. %d) vs %s (Lv. %d)\n", a.Name, a.Level, b.Name, b.Level)
+	result := battle.New(rng, chart).Run(a, b)
+	for _, line := range result.Log {
+		fmt.Println(line)
+	}
+	// Only wild pokemon give experience and prize money.
+	if result.Winner == a && !owned {
+		return rewardWin(ctx, c, mine, theirs.Pokemon, b)
+	}
+	return nil
+}
+
+// commandAttack fights one round against the wild pokemon being faced. Its
+// HP and status carry over to the next attack and to catch, where a weak or
+// ailing pokemon is easier to catch.
+func commandAttack(ctx context.Context, c *session.Session, args []string, _ map[string]string) error {
+	if c.Wild == nil {
+		return errors.New("There's no wild Pokemon here - use encounter to look for one.")
+	}
+	var mine session.CaughtPokemon
+	var err error
+	if len(args) > 0 {
+		mine, err = c.FindOwned(args[0])
+	} else if len(c.Party) > 0 {
+		mine, err = c.FindOwned(strconv.Itoa(c.Party[0]))
+	} else {
+		err = errors.New("You don't have a Pokemon in your party to attack with.")
+	}
+	if err != nil {
+		return err
+	}
+	poke, err := c.Client.GetPokemon(ctx, c.Wild.Name)
+	if err != nil {
+		return err
+	}
+	theirs := session.CaughtPokemon{
+		Pokemon:    poke,
+		Level:      c.Wild.Level,
+		NatureName: stats.RandomNature(c.RNG).Name,
+		IVs:        stats.RandomIVs(c.RNG),
+	}
+	chart, err := c.TypeChart(ctx, 0)
+	if err != nil {
+		return err
+	}
+	a, err := combatant(ctx, c, mine)
+	if err != nil {
+		return err
+	}
+	b, err := combatant(ctx, c, theirs)
+	if err != nil {
+		return err
+	}
+	b.Name = "wild " + b.Name
+	a.HP = a.Stats.HP
+	b.HP = b.Stats.HP
+	if c.Wild.MaxHP > 0 {
+		b.Stats.HP, b.HP = c.Wild.MaxHP, c.Wild.HP
+	}
+	b.Status = c.Wild.Status
+	result := battle.New(c.RNG, chart).Round(a, b)
+	for _, line := range result.Log {
+		fmt.Println(line)
+	}
+	switch result.Winner {
+	case a:
+		c.Wild = nil
+		return rewardWin(ctx, c, mine, poke, b)
+	case b:
+		fmt.Printf("The wild %s got away.\n", poke.Name)
+		c.Wild = nil
+	default:
+		c.Wild.HP, c.Wild.MaxHP, c.Wild.Status = b.HP, b.Stats.HP, b.Status
+	}
+	return nil
+}
+
+// rewardWin pays the prize for beating the wild pokemon defeated and gives
+// mine its experience.
+func rewardWin(ctx context.Context, c *session.Session, mine session.CaughtPokemon, defeated pokeapi.Pokemon, b *battle.Combatant) error {
+	prize := b.Level * prizePerLevel
+	c.Money += prize
+	fmt.Printf("You won %d for beating %s.\n", prize, b.Name)
+	return gainExperience(ctx, c, mine.ID, defeated, b.Level)
+}
+
+func combatant(ctx context.Context, c *session.Session, p session.CaughtPokemon) (*battle.Combatant, error) {
+	level := p.CurrentLevel()
+	moves, err := battleMoves(ctx, c, p.Pokemon, level)
+	if err != nil {
+		return nil, err
+	}
+	actual := p.Stats()
+	return &battle.Combatant{
+		Name:  p.DisplayName(),
+		Level: level,
+		Types: typechart.PokemonTypes(p.Pokemon, 0),
+		Stats: battle.Stats{
+			HP:        actual.HP,
+			Attack:    actual.Attack,
+			Defense:   actual.Defense,
+			SpAttack:  actual.SpAttack,
+			SpDefense: actual.SpDefense,
+			Speed:     actual.Speed,
+		},
+		Moves: moves,
+	}, nil
+}
+
+// battleMoves picks up to four damaging moves the pokemon learns by level-up
+// at or below level, most recently learned first.
+func battleMoves(ctx context.Context, c *session.Session, poke pokeapi.Pokemon, level int) ([]battle.Move, error) {
+	type learned struct {
+		name  string
+		level int
+	}
+	var candidates []learned
+	for _, pm := range poke.Moves {
+		best := -1
+		for _, detail := range pm.VersionGroupDetails {
+			if detail.MoveLearnMethod.Name == "level-up" && detail.LevelLearnedAt <= level && detail.LevelLearnedAt > best {
+				best = detail.LevelLearnedAt
+			}
+		}
+		if best >= 0 {
+			candidates = append(candidates, learned{pm.Move.Name, best})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].level > candidates[j].level
+	})
+	var moves []battle.Move
+	for _, cand := range candidates {
+		if len(moves) == maxBattleMoves {
+			break
+		}
+		move, err := c.Client.GetMove(ctx, cand.name)
+		if err != nil {
+			return nil, err
+		}
+		if move.Power == nil || *move.Power == 0 {
+			continue
+		}
+		bm := battle.Move{
+			Name:     move.Name,
+			Type:     move.Type.Name,
+			Power:    *move.Power,
+			Physical: move.DamageClass.Name == "physical",
+			Priority: move.Priority,
+		}
+		if move.Accuracy != nil {
+			bm.Accuracy = *move.Accuracy
+		}
+		if move.Meta != nil {
+			bm.Ailment = move.Meta.Ailment.Name
+			bm.AilmentChance = move.Meta.AilmentChance
+		}
+		moves = append(moves, bm)
+	}
+	return moves, nil
+}
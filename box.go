@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/smwalke83/pokedex/internal/session"
+)
+
+// Pokemon that don't fit in the party are stored in boxes of this size.
+const boxSize = 30
+
+func commandBox(_ context.Context, c *session.Session, args []string, _ map[string]string) error {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+	switch args[0] {
+	case "list":
+		return boxList(c)
+	case "release":
+		if len(args) < 2 {
+			return errors.New("Please enter the ID of the Pokemon to release")
+		}
+		return releasePokemon(c, args[1])
+	}
+	return fmt.Errorf("Invalid command - unknown box action %q, use list or release.", args[0])
+}
+
+func boxList(c *session.Session) error {
+	boxed := c.Boxed()
+	if len(boxed) == 0 {
+		fmt.Println("Your boxes are empty.")
+		return nil
+	}
+	for i, caught := range boxed {
+		if i%boxSize == 0 {
+			fmt.Printf("Box %d:\n", i/boxSize+1)
+		}
+		fmt.Printf(" - %s Lv. %d\n", caught.Label(), caught.CurrentLevel())
+	}
+	return nil
+}
+
+func releasePokemon(c *session.Session, ref string) error {
+	caught, err := c.FindOwned(ref)
+	if err != nil {
+		return err
+	}
+	delete(c.Owned, caught.ID)
+	for i, member := range c.Party {
+		if member == caught.ID {
+			c.Party = append(c.Party[:i], c.Party[i+1:]...)
+			break
+		}
+	}
+	fmt.Printf("%s was released. Bye, %s!\n", caught.Label(), caught.DisplayName())
+	return nil
+}
+
+func commandNickname(_ context.Context, c *session.Session, args []string, _ map[string]string) error {
+	caught, err := c.FindOwned(args[0])
+	if err != nil {
+		return err
+	}
+	nickname := strings.Join(args[1:], " ")
+	if _, err := strconv.Atoi(strings.TrimPrefix(nickname, "#")); err == nil {
+		return errors.New("Invalid command - a nickname can't be a number.")
+	}
+	old := caught.Label()
+	caught.Nickname = nickname
+	c.Owned[caught.ID] = caught
+	if nickname == "" {
+		fmt.Printf("%s no longer has a nickname.\n", old)
+	} else {
+		fmt.Printf("%s is now called %s.\n", old, nickname)
+	}
+	return nil
+}
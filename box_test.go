@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/session"
+)
+
+func TestRelease(t *testing.T) {
+	c := &session.Session{}
+	c.AddOwned(session.CaughtPokemon{Pokemon: pokeapi.Pokemon{Name: "pikachu"}})
+	c.AddOwned(session.CaughtPokemon{Pokemon: pokeapi.Pokemon{Name: "bulbasaur"}})
+	if err := releasePokemon(c, "1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Owned[1]; ok || len(c.Party) != 1 || c.Party[0] != 2 {
+		t.Errorf("Error - after release. Owned - %v, Party - %v", c.Owned, c.Party)
+	}
+	// IDs aren't reused after a release.
+	if caught := c.AddOwned(session.CaughtPokemon{Pokemon: pokeapi.Pokemon{Name: "pikachu"}}); caught.ID != 3 {
+		t.Errorf("Error - next ID. Actual - %v vs Expected - 3", caught.ID)
+	}
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"github.com/smwalke83/pokedex/internal/command"
+	// Packages that add commands register them when imported.
+	_ "github.com/smwalke83/pokedex/internal/shop"
+)
+
+func init() {
+	for _, cmd := range builtinCommands() {
+		command.Register(cmd)
+	}
+}
+
+func builtinCommands() []command.Command {
+	return []command.Command{
+		command.New(command.Spec{
+			Name:        "exit",
+			Aliases:     []string{"quit"},
+			Category:    command.System,
+			Usage:       "exit",
+			Description: "Exit the Pokedex",
+		}, commandExit),
+		command.New(command.Spec{
+			Name:        "help",
+			Category:    command.System,
+			Usage:       "help [command]",
+			Description: "Displays a help message, or the details of one command",
+			Args: []command.Arg{
+				{Name: "command", Description: "command to show the usage, arguments, flags and examples of"},
+			},
+			Examples: []string{"help", "help explore"},
+		}, commandHelp),
+		command.New(command.Spec{
+			Name:        "map",
+			Category:    command.Navigation,
+			Usage:       "map",
+			Description: "Shows the next 20 map locations",
+		}, commandMap),
+		command.New(command.Spec{
+			Name:        "mapb",
+			Category:    command.Navigation,
+			Usage:       "mapb",
+			Description: "Shows the previous 20 map locations",
+		}, commandMapb),
+		command.New(command.Spec{
+			Name:        "explore",
+			Category:    command.Navigation,
+			Usage:       "explore <area> [--details] [--version red] [--sort rarity|name]",
+			Description: "Shows a list of all the Pokemon in the provided map location",
+			Args: []command.Arg{
+				{Name: "area", Description: "location area to explore", Required: true},
+			},
+			Flags: []command.Flag{
+				{Name: "details", Description: "show level ranges and chances for each encounter method"},
+				{Name: "version", Description: "only show encounters in this game version", Value: true},
+				{Name: "sort", Description: "order the --details tables by rarity or name", Value: true},
+			},
+			Examples: []string{"explore canalave-city-area", "explore viridian-forest-area --details --version red --sort rarity"},
+		}, commandExplore),
+		command.New(command.Spec{
+			Name:        "where",
+			Category:    command.Navigation,
+			Usage:       "where <pokemon> [--version red]",
+			Description: "Shows where a Pokemon can be found, by game version",
+			Args: []command.Arg{
+				{Name: "pokemon", Description: "pokemon to look for", Required: true},
+			},
+			Flags: []command.Flag{
+				{Name: "version", Description: "only show this game version", Value: true},
+			},
+			Examples: []string{"where pikachu", "where pikachu --version yellow"},
+		}, commandWhere),
+		command.New(command.Spec{
+			Name:        "travel",
+			Category:    command.Navigation,
+			Usage:       "travel <area> [--version red]",
+			Description: "Travel to a map location",
+			Args: []command.Arg{
+				{Name: "area", Description: "location area to travel to", Required: true},
+			},
+			Flags: []command.Flag{
+				{Name: "version", Description: "game version to play the area in", Value: true},
+			},
+			Examples: []string{"travel viridian-forest-area --version red"},
+		}, commandTravel),
+		command.New(command.Spec{
+			Name:        "encounter",
+			Category:    command.Collection,
+			Usage:       "encounter [walk|surf|old-rod|...]",
+			Description: "Look for a wild Pokemon where you are",
+			Args: []command.Arg{
+				{Name: "method", Description: "how to look for pokemon, walk by default"},
+			},
+			Examples: []string{"encounter", "encounter old-rod"},
+		}, commandEncounter),
+		command.New(command.Spec{
+			Name:        "catch",
+			Category:    command.Collection,
+			Usage:       "catch [pokemon] [--ball great-ball]",
+			Description: "Throw a pokeball at the wild Pokemon you're facing",
+			Args: []command.Arg{
+				{Name: "pokemon", Description: "the wild pokemon you're facing"},
+			},
+			Flags: []command.Flag{
+				{Name: "ball", Description: "ball to throw, poke-ball by default", Value: true},
+			},
+			Examples: []string{"catch", "catch pikachu --ball great-ball"},
+		}, commandCatch),
+		command.New(command.Spec{
+			Name:        "bag",
+			Category:    command.Collection,
+			Usage:       "bag",
+			Description: "List the items in your bag and your money",
+		}, commandBag),
+		command.New(command.Spec{
+			Name:        "inspect",
+			Category:    command.Collection,
+			Usage:       "inspect <id|nickname|pokemon>",
+			Description: "Learn about a pokemon you own",
+			Args: []command.Arg{
+				{Name: "pokemon", Description: "ID, nickname or species of one of your pokemon", Required: true},
+			},
+			Examples: []string{"inspect 3", "inspect sparky"},
+		}, commandInspect),
+		command.New(command.Spec{
+			Name:        "save",
+			Category:    command.System,
+			Usage:       "save [file]",
+			Description: "Save your pokedex and map position to a file",
+			Args: []command.Arg{
+				{Name: "file", Description: "save file, the -save path by default"},
+			},
+			Examples: []string{"save", "save backup.json"},
+		}, commandSave),
+		command.New(command.Spec{
+			Name:        "load",
+			Category:    command.System,
+			Usage:       "load [file]",
+			Description: "Load a previously saved pokedex and map position",
+			Args: []command.Arg{
+				{Name: "file", Description: "save file, the -save path by default"},
+			},
+			Examples: []string{"load", "load backup.json"},
+		}, commandLoad),
+		command.New(command.Spec{
+			Name:        "evolution",
+			Category:    command.Collection,
+			Usage:       "evolution <pokemon>",
+			Description: "Shows a Pokemon's evolution chain and what triggers each evolution",
+			Args: []command.Arg{
+				{Name: "pokemon", Description: "any pokemon in the chain", Required: true},
+			},
+			Examples: []string{"evolution eevee"},
+		}, commandEvolution),
+		command.New(command.Spec{
+			Name:        "evolve",
+			Category:    command.Collection,
+			Usage:       "evolve <id|nickname|pokemon> [--to species] [--trade]",
+			Description: "Evolve a caught Pokemon once it meets the conditions, such as a level, friendship or an item from buy",
+			Args: []command.Arg{
+				{Name: "pokemon", Description: "ID, nickname or species of one of your pokemon", Required: true},
+			},
+			Flags: []command.Flag{
+				{Name: "to", Description: "species to evolve into when there's a choice", Value: true},
+				{Name: "trade", Description: "trade the pokemon to a friend and back, for trade evolutions"},
+			},
+			Examples: []string{"evolve 3", "evolve eevee --to vaporeon", "evolve machoke --trade"},
+		}, commandEvolve),
+		command.New(command.Spec{
+			Name:        "matchup",
+			Category:    command.Battle,
+			Usage:       "matchup <attacker> <defender> [--gen 4]",
+			Description: "Shows how effective one Pokemon's types are against another's",
+			Args: []command.Arg{
+				{Name: "attacker", Description: "attacking pokemon", Required: true},
+				{Name: "defender", Description: "defending pokemon", Required: true},
+			},
+			Flags: []command.Flag{
+				{Name: "gen", Description: "use the type chart of this generation", Value: true},
+			},
+			Examples: []string{"matchup pikachu gyarados", "matchup pikachu gyarados --gen 1"},
+		}, commandMatchup),
+		command.New(command.Spec{
+			Name:        "weakness",
+			Category:    command.Battle,
+			Usage:       "weakness <pokemon> [--gen 4]",
+			Description: "Lists the types a Pokemon is weak or resistant to",
+			Args: []command.Arg{
+				{Name: "pokemon", Description: "defending pokemon", Required: true},
+			},
+			Flags: []command.Flag{
+				{Name: "gen", Description: "use the type chart of this generation", Value: true},
+			},
+			Examples: []string{"weakness charizard", "weakness charizard --gen 1"},
+		}, commandWeakness),
+		command.New(command.Spec{
+			Name:        "party",
+			Category:    command.Battle,
+			Usage:       "party [list|add <id>|remove <id>|analyze] [--gen 4]",
+			Description: "Manage and analyze your party of up to 6 Pokemon",
+			Args: []command.Arg{
+				{Name: "action", Description: "list, add, remove or analyze, list by default"},
+				{Name: "pokemon", Description: "ID, nickname or species to add or remove"},
+			},
+			Flags: []command.Flag{
+				{Name: "gen", Description: "use the type chart of this generation for analyze", Value: true},
+			},
+			Examples: []string{"party", "party add 3", "party remove sparky", "party analyze --gen 4"},
+		}, commandParty),
+		command.New(command.Spec{
+			Name:        "battle",
+			Category:    command.Battle,
+			Usage:       "battle <id> <pokemon|id> [--seed 42]",
+			Description: "Simulates a battle between one of your Pokemon and a wild one or another of yours",
+			Args: []command.Arg{
+				{Name: "mine", Description: "ID, nickname or species of your pokemon", Required: true},
+				{Name: "opponent", Description: "a wild pokemon, or the ID of another of yours", Required: true},
+			},
+			Flags: []command.Flag{
+				{Name: "seed", Description: "seed this battle's random rolls", Value: true},
+			},
+			Examples: []string{"battle 1 gyarados", "battle 1 2 --seed 42"},
+		}, commandBattle),
+		command.New(command.Spec{
+			Name:        "attack",
+			Category:    command.Battle,
+			Usage:       "attack [id|nickname|pokemon]",
+			Description: "Fight one round against the wild Pokemon you're facing to weaken it before catching it",
+			Args: []command.Arg{
+				{Name: "pokemon", Description: "ID, nickname or species of your pokemon, the first in your party by default"},
+			},
+			Examples: []string{"attack", "attack sparky"},
+		}, commandAttack),
+		command.New(command.Spec{
+			Name:        "box",
+			Aliases:     []string{"pc"},
+			Category:    command.Collection,
+			Usage:       "box [list|release <id>]",
+			Description: "List the Pokemon stored outside your party, or release one",
+			Args: []command.Arg{
+				{Name: "action", Description: "list or release, list by default"},
+				{Name: "pokemon", Description: "ID, nickname or species to release"},
+			},
+			Examples: []string{"box", "box release 7"},
+		}, commandBox),
+		command.New(command.Spec{
+			Name:        "nickname",
+			Category:    command.Collection,
+			Usage:       "nickname <id> [name]",
+			Description: "Give one of your Pokemon a nickname, or clear it",
+			Args: []command.Arg{
+				{Name: "pokemon", Description: "ID, nickname or species of your pokemon", Required: true},
+				{Name: "name", Description: "new nickname, quoted to keep its case; none clears it", Variadic: true},
+			},
+			Examples: []string{`nickname 3 "Sparky"`, "nickname 3"},
+		}, commandNickname),
+		command.New(command.Spec{
+			Name:        "pokedex",
+			Aliases:     []string{"dex"},
+			Category:    command.Collection,
+			Usage:       "pokedex",
+			Description: "View the species you've caught",
+		}, commandPokedex),
+		command.New(command.Spec{
+			Name:        "progress",
+			Category:    command.Collection,
+			Usage:       "progress [kanto|original-johto|hoenn|...]",
+			Description: "Shows how many Pokemon you've seen and caught, overall or in one pokedex",
+			Args: []command.Arg{
+				{Name: "pokedex", Description: "regional pokedex to list the missing pokemon of"},
+			},
+			Examples: []string{"progress", "progress kanto"},
+		}, commandProgress),
+		command.New(command.Spec{
+			Name:        "seed",
+			Category:    command.System,
+			Usage:       "seed [number]",
+			Description: "Shows the random seed, or restarts the random number generator from a new one",
+			Args: []command.Arg{
+				{Name: "seed", Description: "new seed"},
+			},
+			Examples: []string{"seed", "seed 42"},
+		}, commandSeed),
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/smwalke83/pokedex/internal/command"
+)
+
+func TestBuiltinCommandsHaveHelp(t *testing.T) {
+	for _, cmd := range builtinCommands() {
+		spec := cmd.Spec()
+		if !slices.Contains(command.Categories, spec.Category) {
+			t.Errorf("Error - %s has an unknown category %q", spec.Name, spec.Category)
+		}
+		if spec.Description == "" || !strings.HasPrefix(spec.Usage, spec.Name) {
+			t.Errorf("Error - %s has no description or its usage %q doesn't start with its name", spec.Name, spec.Usage)
+		}
+		for _, example := range spec.Examples {
+			if !strings.HasPrefix(example, spec.Name) {
+				t.Errorf("Error - %s example %q doesn't start with its name", spec.Name, example)
+			}
+		}
+	}
+}
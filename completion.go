@@ -0,0 +1,82 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/smwalke83/pokedex/internal/command"
+	"github.com/smwalke83/pokedex/internal/lineedit"
+	"github.com/smwalke83/pokedex/internal/session"
+)
+
+// argCompletions lists, for commands whose first argument names something
+// the session knows about, where the candidates for it come from.
+var argCompletions = map[string]func(c *session.Session) []string{
+	"inspect":  ownedNames,
+	"evolve":   ownedNames,
+	"nickname": ownedNames,
+	"explore":  areaNames,
+	"travel":   areaNames,
+	"help":     commandNames,
+}
+
+// completer completes command names as the first word of a command and the
+// first argument of the commands in argCompletions.
+func completer(c *session.Session) lineedit.Completer {
+	return func(line string) []string {
+		// Only the last of several commands separated by semicolons is
+		// being typed.
+		if i := strings.LastIndex(line, ";"); i >= 0 {
+			line = line[i+1:]
+		}
+		words := strings.Fields(line)
+		if len(words) == 0 || (len(words) == 1 && !strings.HasSuffix(line, " ")) {
+			return commandNames(c)
+		}
+		arg := len(words) - 1
+		if unicode.IsSpace(rune(line[len(line)-1])) {
+			arg++
+		}
+		cmd, ok := command.Default.Lookup(strings.ToLower(words[0]))
+		if !ok || arg != 1 {
+			return nil
+		}
+		complete, ok := argCompletions[cmd.Spec().Name]
+		if !ok {
+			return nil
+		}
+		return complete(c)
+	}
+}
+
+func commandNames(_ *session.Session) []string {
+	names := []string{}
+	for _, cmd := range command.Default.Commands() {
+		names = append(names, cmd.Spec().Name)
+		names = append(names, cmd.Spec().Aliases...)
+	}
+	return names
+}
+
+// ownedNames returns the species and nicknames of the trainer's pokemon.
+// Nicknames with spaces are left out since they'd need quoting.
+func ownedNames(c *session.Session) []string {
+	names := []string{}
+	for _, caught := range c.OwnedByID() {
+		names = append(names, caught.Pokemon.Name)
+		if caught.Nickname != "" && !strings.ContainsFunc(caught.Nickname, unicode.IsSpace) {
+			names = append(names, strings.ToLower(caught.Nickname))
+		}
+	}
+	return names
+}
+
+// areaNames returns the location areas on the current map page, where the
+// trainer is, and any the client has cached.
+func areaNames(c *session.Session) []string {
+	names := append([]string{}, c.Areas...)
+	if c.Location != "" {
+		names = append(names, c.Location)
+	}
+	return append(names, c.Client.CachedLocationAreas()...)
+}
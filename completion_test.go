@@ -0,0 +1,44 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/session"
+)
+
+func TestCompleter(t *testing.T) {
+	c := session.New(pokeapi.NewClient("http://127.0.0.1:0", nil, nil))
+	c.AddOwned(session.CaughtPokemon{Nickname: "Sparky", Pokemon: pokeapi.Pokemon{Name: "pikachu"}})
+	c.AddOwned(session.CaughtPokemon{Nickname: "Sir Sparks", Pokemon: pokeapi.Pokemon{Name: "raichu"}})
+	c.Areas = []string{"canalave-city-area", "eterna-city-area"}
+	complete := completer(c)
+	cases := []struct {
+		line     string
+		contains []string
+		excludes []string
+	}{
+		{line: "", contains: []string{"catch", "dex", "quit"}},
+		{line: "insp", contains: []string{"inspect", "explore"}},
+		{line: "inspect ", contains: []string{"pikachu", "sparky", "raichu"}, excludes: []string{"sir sparks"}},
+		{line: "bag; evolve ra", contains: []string{"raichu"}},
+		{line: "explore can", contains: []string{"canalave-city-area"}},
+		{line: "help ex", contains: []string{"explore", "exit"}},
+		{line: "inspect pikachu ", excludes: []string{"pikachu"}},
+		{line: "catch ", excludes: []string{"pikachu"}},
+	}
+	for _, c := range cases {
+		actual := complete(c.line)
+		for _, name := range c.contains {
+			if !slices.Contains(actual, name) {
+				t.Errorf("Error - Completions for %q: Actual - %v should contain %s", c.line, actual, name)
+			}
+		}
+		for _, name := range c.excludes {
+			if slices.Contains(actual, name) {
+				t.Errorf("Error - Completions for %q: Actual - %v shouldn't contain %s", c.line, actual, name)
+			}
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/smwalke83/pokedex/internal/inventory"
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/session"
+)
+
+func commandEvolution(ctx context.Context, c *session.Session, args []string, _ map[string]string) error {
+	species, err := resolveSpecies(ctx, c, args[0])
+	if err != nil {
+		return err
+	}
+	chain, err := evolutionChain(ctx, c, species)
+	if err != nil {
+		return err
+	}
+	fmt.Print(renderChain(chain.Chain))
+	return nil
+}
+
+func commandEvolve(ctx context.Context, c *session.Session, args []string, flags map[string]string) error {
+	caught, err := c.FindOwned(args[0])
+	if err != nil {
+		return err
+	}
+	name := caught.DisplayName()
+	chain, err := evolutionChain(ctx, c, caught.SpeciesName())
+	if err != nil {
+		return err
+	}
+	link := findLink(chain.Chain, caught.SpeciesName())
+	if link == nil || len(link.EvolvesTo) == 0 {
+		return fmt.Errorf("%s doesn't evolve.", name)
+	}
+	type candidate struct {
+		species string
+		detail  pokeapi.EvolutionDetail
+	}
+	candidates := []candidate{}
+	reasons := []string{}
+	trading := flags["trade"] != ""
+	hour := time.Now().Hour()
+	for _, next := range link.EvolvesTo {
+		if flags["to"] != "" && next.Species.Name != flags["to"] {
+			continue
+		}
+		for _, detail := range next.EvolutionDetails {
+			ok, reason := checkEvolution(detail, caught, c.Bag, trading, hour)
+			if ok {
+				candidates = append(candidates, candidate{species: next.Species.Name, detail: detail})
+				break
+			}
+			reasons = append(reasons, fmt.Sprintf("%s %s", next.Species.Name, reason))
+		}
+	}
+	if len(candidates) == 0 {
+		if len(reasons) == 0 {
+			return fmt.Errorf("%s can't evolve into %s.", name, flags["to"])
+		}
+		return fmt.Errorf("%s can't evolve yet: %s", name, strings.Join(reasons, "; "))
+	}
+	if len(candidates) > 1 {
+		names := make([]string, 0, len(candidates))
+		for _, cand := range candidates {
+			names = append(names, cand.species)
+		}
+		return fmt.Errorf("%s can evolve into %s - choose one with --to.", name, strings.Join(names, " or "))
+	}
+	species, err := c.Client.GetPokemonSpecies(ctx, candidates[0].species)
+	if err != nil {
+		return err
+	}
+	evolved, err := c.Client.GetPokemon(ctx, defaultVariety(species))
+	if err != nil {
+		return err
+	}
+	// Evolution stones are used up, and so are the items the bag stands in
+	// for as held items.
+	for _, item := range []*pokeapi.NamedAPIResource{candidates[0].detail.Item, candidates[0].detail.HeldItem} {
+		if item == nil {
+			continue
+		}
+		if err := c.Bag.Use(item.Name); err != nil {
+			return err
+		}
+	}
+	if trading {
+		fmt.Printf("You traded %s to a friend, who traded it back.\n", name)
+	}
+	caught.Pokemon = evolved
+	c.Owned[caught.ID] = caught
+	c.MarkCaught(species.Name)
+	fmt.Printf("Congratulations! Your %s evolved into %s!\n", name, evolved.Name)
+	return nil
+}
+
+// resolveSpecies turns a typed species, or the name of one of its forms such
+// as lycanroc-dusk, into the species name.
+func resolveSpecies(ctx context.Context, c *session.Session, name string) (string, error) {
+	species, err := c.ResolveName(ctx, "pokemon-species", name)
+	if err == nil {
+		return species, nil
+	}
+	form, formErr := c.ResolveName(ctx, "pokemon", name)
+	if formErr != nil {
+		return "", err
+	}
+	return c.SpeciesOf(ctx, form)
+}
+
+// evolutionChain fetches the evolution chain of a species.
+func evolutionChain(ctx context.Context, c *session.Session, species string) (pokeapi.EvolutionChain, error) {
+	s, err := c.Client.GetPokemonSpecies(ctx, species)
+	if err != nil {
+		return pokeapi.EvolutionChain{}, err
+	}
+	return c.Client.GetEvolutionChain(ctx, s.EvolutionChain.URL)
+}
+
+// defaultVariety returns the name of the pokemon a species evolves into, e.g.
+// toxtricity-amped for toxtricity, which has no pokemon of the same name.
+func defaultVariety(species pokeapi.PokemonSpecies) string {
+	for _, variety := range species.Varieties {
+		if variety.IsDefault {
+			return variety.Pokemon.Name
+		}
+	}
+	return species.Name
+}
+
+func findLink(link pokeapi.ChainLink, species string) *pokeapi.ChainLink {
+	if link.Species.Name == species {
+		return &link
+	}
+	for _, next := range link.EvolvesTo {
+		if found := findLink(next, species); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func renderChain(link pokeapi.ChainLink) string {
+	var b strings.Builder
+	b.WriteString(link.Species.Name + "\n")
+	renderLinks(&b, link.EvolvesTo, "")
+	return b.String()
+}
+
+func renderLinks(b *strings.Builder, links []pokeapi.ChainLink, indent string) {
+	for i, link := range links {
+		branch, childIndent := "├─ ", "│  "
+		if i == len(links)-1 {
+			branch, childIndent = "└─ ", "   "
+		}
+		triggers := make([]string, 0, len(link.EvolutionDetails))
+		for _, detail := range link.EvolutionDetails {
+			triggers = append(triggers, describeEvolution(detail))
+		}
+		fmt.Fprintf(b, "%s%s%s: %s\n", indent, branch, link.Species.Name, strings.Join(triggers, " or "))
+		renderLinks(b, link.EvolvesTo, indent+childIndent)
+	}
+}
+
+// describeEvolution renders a detail as its trigger followed by its
+// conditions, e.g. "level-up (level 16)" or "use-item (thunder-stone)".
+func describeEvolution(d pokeapi.EvolutionDetail) string {
+	conditions := evolutionConditions(d)
+	if len(conditions) == 0 {
+		return d.Trigger.Name
+	}
+	return fmt.Sprintf("%s (%s)", d.Trigger.Name, strings.Join(conditions, ", "))
+}
+
+func evolutionConditions(d pokeapi.EvolutionDetail) []string {
+	conditions := []string{}
+	if d.MinLevel != nil {
+		conditions = append(conditions, fmt.Sprintf("level %d", *d.MinLevel))
+	}
+	if d.Item != nil {
+		conditions = append(conditions, d.Item.Name)
+	}
+	if d.HeldItem != nil {
+		conditions = append(conditions, "holding "+d.HeldItem.Name)
+	}
+	if d.KnownMove != nil {
+		conditions = append(conditions, "knowing "+d.KnownMove.Name)
+	}
+	if d.KnownMoveType != nil {
+		conditions = append(conditions, "knowing a "+d.KnownMoveType.Name+" move")
+	}
+	if d.Location != nil {
+		conditions = append(conditions, "at "+d.Location.Name)
+	}
+	if d.MinHappiness != nil {
+		conditions = append(conditions, fmt.Sprintf("friendship %d", *d.MinHappiness))
+	}
+	if d.MinBeauty != nil {
+		conditions = append(conditions, fmt.Sprintf("beauty %d", *d.MinBeauty))
+	}
+	if d.MinAffection != nil {
+		conditions = append(conditions, fmt.Sprintf("affection %d", *d.MinAffection))
+	}
+	if d.TimeOfDay != "" {
+		conditions = append(conditions, d.TimeOfDay)
+	}
+	if d.Gender != nil {
+		if *d.Gender == 1 {
+			conditions = append(conditions, "female")
+		} else {
+			conditions = append(conditions, "male")
+		}
+	}
+	if d.NeedsOverworldRain {
+		conditions = append(conditions, "while raining")
+	}
+	if d.PartySpecies != nil {
+		conditions = append(conditions, "with "+d.PartySpecies.Name+" in the party")
+	}
+	if d.PartyType != nil {
+		conditions = append(conditions, "with a "+d.PartyType.Name+" type in the party")
+	}
+	if d.RelativePhysicalStats != nil {
+		switch *d.RelativePhysicalStats {
+		case 1:
+			conditions = append(conditions, "attack > defense")
+		case -1:
+			conditions = append(conditions, "attack < defense")
+		default:
+			conditions = append(conditions, "attack = defense")
+		}
+	}
+	if d.TradeSpecies != nil {
+		conditions = append(conditions, "for "+d.TradeSpecies.Name)
+	}
+	if d.TurnUpsideDown {
+		conditions = append(conditions, "upside down")
+	}
+	return conditions
+}
+
+// checkEvolution reports whether caught meets the conditions of d. Level-up
+// and item evolutions happen through evolve; trade evolutions only when
+// trading is set. Friendship comes from battles and level-ups, items from
+// the bag, which also stands in for held items, and the time of day from
+// hour. Anything else, such as beauty or knowing a move, can't be met.
+func checkEvolution(d pokeapi.EvolutionDetail, caught session.CaughtPokemon, bag inventory.Bag, trading bool, hour int) (bool, string) {
+	rest := d
+	rest.MinLevel = nil
+	rest.Item = nil
+	rest.HeldItem = nil
+	rest.MinHappiness = nil
+	rest.TimeOfDay = ""
+	if len(evolutionConditions(rest)) > 0 {
+		return false, "needs " + describeEvolution(d)
+	}
+	switch d.Trigger.Name {
+	case "level-up", "use-item":
+		if trading {
+			return false, "doesn't evolve by trading"
+		}
+	case "trade":
+		if !trading {
+			return false, "needs trading - use evolve --trade"
+		}
+	default:
+		return false, "needs " + describeEvolution(d)
+	}
+	if d.Trigger.Name == "use-item" && d.Item == nil {
+		return false, "needs " + describeEvolution(d)
+	}
+	if d.MinLevel != nil && caught.CurrentLevel() < *d.MinLevel {
+		return false, fmt.Sprintf("needs level %d (currently %d)", *d.MinLevel, caught.CurrentLevel())
+	}
+	if d.MinHappiness != nil && caught.Friendship < *d.MinHappiness {
+		return false, fmt.Sprintf("needs friendship %d (currently %d)", *d.MinHappiness, caught.Friendship)
+	}
+	if d.TimeOfDay != "" && !atTimeOfDay(d.TimeOfDay, hour) {
+		return false, "only evolves at " + d.TimeOfDay
+	}
+	if d.Item != nil && bag.Count(d.Item.Name) == 0 {
+		return false, "needs a " + d.Item.Name + ", which you can buy"
+	}
+	if d.HeldItem != nil && bag.Count(d.HeldItem.Name) == 0 {
+		return false, "needs to hold a " + d.HeldItem.Name + ", which you can buy"
+	}
+	return true, ""
+}
+
+// atTimeOfDay reports whether hour falls in part, one of the times of day
+// evolution details name. Dusk is the last hour of the day.
+func atTimeOfDay(part string, hour int) bool {
+	switch part {
+	case "day":
+		return hour >= 6 && hour < 18
+	case "dusk":
+		return hour == 17
+	case "night":
+		return hour < 6 || hour >= 18
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/smwalke83/pokedex/internal/inventory"
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/session"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func testChain() pokeapi.ChainLink {
+	return pokeapi.ChainLink{
+		Species: pokeapi.NamedAPIResource{Name: "pichu"},
+		EvolvesTo: []pokeapi.ChainLink{
+			{
+				Species: pokeapi.NamedAPIResource{Name: "pikachu"},
+				EvolutionDetails: []pokeapi.EvolutionDetail{
+					{Trigger: pokeapi.NamedAPIResource{Name: "level-up"}, MinHappiness: intPtr(220)},
+				},
+				EvolvesTo: []pokeapi.ChainLink{
+					{
+						Species: pokeapi.NamedAPIResource{Name: "raichu"},
+						EvolutionDetails: []pokeapi.EvolutionDetail{
+							{Trigger: pokeapi.NamedAPIResource{Name: "use-item"}, Item: &pokeapi.NamedAPIResource{Name: "thunder-stone"}},
+						},
+					},
+					{
+						Species: pokeapi.NamedAPIResource{Name: "raichu-alola"},
+						EvolutionDetails: []pokeapi.EvolutionDetail{
+							{Trigger: pokeapi.NamedAPIResource{Name: "use-item"}, Item: &pokeapi.NamedAPIResource{Name: "thunder-stone"}, Location: &pokeapi.NamedAPIResource{Name: "alola"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRenderChain(t *testing.T) {
+	expected := "pichu\n" +
+		"└─ pikachu: level-up (friendship 220)\n" +
+		"   ├─ raichu: use-item (thunder-stone)\n" +
+		"   └─ raichu-alola: use-item (thunder-stone, at alola)\n"
+	actual := renderChain(testChain())
+	if actual != expected {
+		t.Errorf("Error - Trees Don't Match:\nActual -\n%s\nExpected -\n%s", actual, expected)
+	}
+}
+
+func TestFindLink(t *testing.T) {
+	link := findLink(testChain(), "pikachu")
+	if link == nil || len(link.EvolvesTo) != 2 {
+		t.Fatalf("Error - Expected to find pikachu with two evolutions, got %+v", link)
+	}
+	if findLink(testChain(), "bulbasaur") != nil {
+		t.Errorf("Error - Expected not to find bulbasaur")
+	}
+}
+
+func TestCheckEvolution(t *testing.T) {
+	levelUp := pokeapi.EvolutionDetail{Trigger: pokeapi.NamedAPIResource{Name: "level-up"}, MinLevel: intPtr(16)}
+	stone := pokeapi.EvolutionDetail{Trigger: pokeapi.NamedAPIResource{Name: "use-item"}, Item: &pokeapi.NamedAPIResource{Name: "thunder-stone"}}
+	trade := pokeapi.EvolutionDetail{Trigger: pokeapi.NamedAPIResource{Name: "trade"}}
+	tradeHolding := pokeapi.EvolutionDetail{Trigger: pokeapi.NamedAPIResource{Name: "trade"}, HeldItem: &pokeapi.NamedAPIResource{Name: "metal-coat"}}
+	espeon := pokeapi.EvolutionDetail{Trigger: pokeapi.NamedAPIResource{Name: "level-up"}, MinHappiness: intPtr(160), TimeOfDay: "day"}
+	move := pokeapi.EvolutionDetail{Trigger: pokeapi.NamedAPIResource{Name: "level-up"}, KnownMove: &pokeapi.NamedAPIResource{Name: "ancient-power"}}
+	cases := []struct {
+		detail     pokeapi.EvolutionDetail
+		level      int
+		friendship int
+		bag        inventory.Bag
+		trading    bool
+		hour       int
+		expected   bool
+	}{
+		{detail: levelUp, level: 15, bag: inventory.Bag{}, expected: false},
+		{detail: levelUp, level: 16, bag: inventory.Bag{}, expected: true},
+		// Pokemon from saves without levels are at session.DefaultLevel.
+		{detail: levelUp, level: 0, bag: inventory.Bag{}, expected: true},
+		{detail: levelUp, level: 16, bag: inventory.Bag{}, trading: true, expected: false},
+		{detail: stone, level: 5, bag: inventory.Bag{}, expected: false},
+		{detail: stone, level: 5, bag: inventory.Bag{"thunder-stone": 1}, expected: true},
+		{detail: trade, level: 100, bag: inventory.Bag{}, expected: false},
+		{detail: trade, level: 5, bag: inventory.Bag{}, trading: true, expected: true},
+		{detail: tradeHolding, level: 5, bag: inventory.Bag{}, trading: true, expected: false},
+		{detail: tradeHolding, level: 5, bag: inventory.Bag{"metal-coat": 1}, trading: true, expected: true},
+		{detail: espeon, level: 5, friendship: 159, bag: inventory.Bag{}, hour: 12, expected: false},
+		{detail: espeon, level: 5, friendship: 160, bag: inventory.Bag{}, hour: 12, expected: true},
+		{detail: espeon, level: 5, friendship: 160, bag: inventory.Bag{}, hour: 22, expected: false},
+		{detail: move, level: 100, bag: inventory.Bag{}, expected: false},
+	}
+	for _, c := range cases {
+		caught := session.CaughtPokemon{Level: c.level, Friendship: c.friendship}
+		actual, reason := checkEvolution(c.detail, caught, c.bag, c.trading, c.hour)
+		if actual != c.expected {
+			t.Errorf("Error - %s at level %d, friendship %d, trading %v, hour %d: Actual - %v (%s) vs Expected - %v", describeEvolution(c.detail), c.level, c.friendship, c.trading, c.hour, actual, reason, c.expected)
+		}
+	}
+}
+
+func TestAtTimeOfDay(t *testing.T) {
+	cases := []struct {
+		part     string
+		hour     int
+		expected bool
+	}{
+		{part: "day", hour: 6, expected: true},
+		{part: "day", hour: 17, expected: true},
+		{part: "dusk", hour: 17, expected: true},
+		{part: "dusk", hour: 12, expected: false},
+		{part: "night", hour: 18, expected: true},
+		{part: "night", hour: 5, expected: true},
+		{part: "night", hour: 12, expected: false},
+	}
+	for _, c := range cases {
+		if actual := atTimeOfDay(c.part, c.hour); actual != c.expected {
+			t.Errorf("Error - %s at %d:00. Actual - %v vs Expected - %v", c.part, c.hour, actual, c.expected)
+		}
+	}
+}
+
+func TestDefaultVariety(t *testing.T) {
+	toxtricity := pokeapi.PokemonSpecies{Name: "toxtricity", Varieties: []pokeapi.PokemonSpeciesVariety{
+		{Pokemon: pokeapi.NamedAPIResource{Name: "toxtricity-low-key"}},
+		{IsDefault: true, Pokemon: pokeapi.NamedAPIResource{Name: "toxtricity-amped"}},
+	}}
+	cases := []struct {
+		species  pokeapi.PokemonSpecies
+		expected string
+	}{
+		{species: toxtricity, expected: "toxtricity-amped"},
+		{species: pokeapi.PokemonSpecies{Name: "raichu"}, expected: "raichu"},
+	}
+	for _, c := range cases {
+		actual := defaultVariety(c.species)
+		if actual != c.expected {
+			t.Errorf("Error - %s: Actual - %s vs Expected - %s", c.species.Name, actual, c.expected)
+		}
+	}
+}
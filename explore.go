@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/session"
+)
+
+type pokemonRow struct {
+	Pokemon  string
+	MinLevel int
+	MaxLevel int
+	Chance   int
+}
+
+type methodTable struct {
+	Method string
+	// Rate is the chance per step (or per cast, per surf...) of running
+	// into any pokemon with this method, when PokeAPI reports one.
+	Rate int
+	Rows []pokemonRow
+}
+
+func commandExplore(ctx context.Context, c *session.Session, args []string, flags map[string]string) error {
+	sortBy := flags["sort"]
+	if sortBy != "" && sortBy != "rarity" && sortBy != "name" {
+		return fmt.Errorf("Invalid command - cannot sort by %q, use rarity or name.", sortBy)
+	}
+	name, err := c.ResolveName(ctx, "location-area", args[0])
+	if err != nil {
+		return err
+	}
+	loc, err := c.Client.GetLocationArea(ctx, name)
+	if err != nil {
+		return err
+	}
+	version := flags["version"]
+	if flags["details"] != "" {
+		return printEncounterTables(ctx, c, loc, version, sortBy)
+	}
+	for _, result := range loc.PokemonEncounters {
+		found := version == ""
+		for _, details := range result.VersionDetails {
+			if details.Version.Name == version {
+				found = true
+			}
+		}
+		if !found {
+			continue
+		}
+		fmt.Printf("%s\n", result.Pokemon.Name)
+		c.SeePokemon(ctx, result.Pokemon.Name)
+	}
+	return nil
+}
+
+func printEncounterTables(ctx context.Context, c *session.Session, loc pokeapi.LocationArea, version, sortBy string) error {
+	if version == "" {
+		versions := areaVersions(loc)
+		if len(versions) == 0 {
+			fmt.Printf("No pokemon can be found in %s.\n", loc.Name)
+			return nil
+		}
+		version = versions[0]
+	}
+	tables := encounterTables(loc, version)
+	if len(tables) == 0 {
+		fmt.Printf("No pokemon can be found in %s in %s.\n", loc.Name, version)
+		return nil
+	}
+	fmt.Printf("Encounters in %s (%s):\n", loc.Name, version)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	shown := []string{}
+	for _, table := range tables {
+		sortPokemonRows(table.Rows, sortBy)
+		if table.Rate > 0 {
+			fmt.Fprintf(w, "%s (encounter rate %d%%):\n", table.Method, table.Rate)
+		} else {
+			fmt.Fprintf(w, "%s:\n", table.Method)
+		}
+		fmt.Fprintln(w, "  POKEMON\tLEVELS\tCHANCE")
+		for _, row := range table.Rows {
+			fmt.Fprintf(w, "  %s\t%s\t%d%%\n", row.Pokemon, levelRange(row.MinLevel, row.MaxLevel), row.Chance)
+			shown = append(shown, row.Pokemon)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	for _, name := range shown {
+		c.SeePokemon(ctx, name)
+	}
+	return nil
+}
+
+// areaVersions returns the game versions that have encounters in loc, in the
+// order PokeAPI lists them.
+func areaVersions(loc pokeapi.LocationArea) []string {
+	versions := []string{}
+	seen := make(map[string]bool)
+	for _, pe := range loc.PokemonEncounters {
+		for _, vd := range pe.VersionDetails {
+			if !seen[vd.Version.Name] {
+				seen[vd.Version.Name] = true
+				versions = append(versions, vd.Version.Name)
+			}
+		}
+	}
+	return versions
+}
+
+// encounterTables builds one table per encounter method for version,
+// merging each pokemon's encounter slots into a single row.
+func encounterTables(loc pokeapi.LocationArea, version string) []methodTable {
+	tables := []methodTable{}
+	tableIndex := make(map[string]int)
+	rowIndex := make(map[string]int)
+	for _, pe := range loc.PokemonEncounters {
+		for _, vd := range pe.VersionDetails {
+			if vd.Version.Name != version {
+				continue
+			}
+			for _, enc := range vd.EncounterDetails {
+				method := methodLabel(enc)
+				ti, ok := tableIndex[method]
+				if !ok {
+					ti = len(tables)
+					tableIndex[method] = ti
+					tables = append(tables, methodTable{
+						Method: method,
+						Rate:   methodRate(loc, enc.Method.Name, version),
+					})
+				}
+				key := method + "|" + pe.Pokemon.Name
+				ri, ok := rowIndex[key]
+				if !ok {
+					rowIndex[key] = len(tables[ti].Rows)
+					tables[ti].Rows = append(tables[ti].Rows, pokemonRow{
+						Pokemon:  pe.Pokemon.Name,
+						MinLevel: enc.MinLevel,
+						MaxLevel: enc.MaxLevel,
+						Chance:   enc.Chance,
+					})
+					continue
+				}
+				row := &tables[ti].Rows[ri]
+				row.MinLevel = min(row.MinLevel, enc.MinLevel)
+				row.MaxLevel = max(row.MaxLevel, enc.MaxLevel)
+				row.Chance += enc.Chance
+			}
+		}
+	}
+	return tables
+}
+
+func methodRate(loc pokeapi.LocationArea, method, version string) int {
+	for _, emr := range loc.EncounterMethodRates {
+		if emr.EncounterMethod.Name != method {
+			continue
+		}
+		for _, vd := range emr.VersionDetails {
+			if vd.Version.Name == version {
+				return vd.Rate
+			}
+		}
+	}
+	return 0
+}
+
+// sortPokemonRows sorts rows rarest first for "rarity" and alphabetically
+// for "name"; any other value keeps PokeAPI's order.
+func sortPokemonRows(rows []pokemonRow, sortBy string) {
+	switch sortBy {
+	case "rarity":
+		sort.SliceStable(rows, func(i, j int) bool {
+			return rows[i].Chance < rows[j].Chance
+		})
+	case "name":
+		sort.SliceStable(rows, func(i, j int) bool {
+			return rows[i].Pokemon < rows[j].Pokemon
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+)
+
+func TestEncounterTables(t *testing.T) {
+	loc := pokeapi.LocationArea{
+		PokemonEncounters: []pokeapi.PokemonEncounter{
+			{
+				Pokemon: pokeapi.NamedAPIResource{Name: "caterpie"},
+				VersionDetails: []pokeapi.VersionEncounterDetail{
+					{
+						Version: pokeapi.NamedAPIResource{Name: "red"},
+						EncounterDetails: []pokeapi.Encounter{
+							encounter("walk", 3, 3, 30),
+							encounter("walk", 5, 5, 20),
+						},
+					},
+				},
+			},
+			{
+				Pokemon: pokeapi.NamedAPIResource{Name: "pikachu"},
+				VersionDetails: []pokeapi.VersionEncounterDetail{
+					{
+						Version: pokeapi.NamedAPIResource{Name: "red"},
+						EncounterDetails: []pokeapi.Encounter{
+							encounter("walk", 3, 5, 5),
+							encounter("old-rod", 10, 10, 100),
+						},
+					},
+					{
+						Version: pokeapi.NamedAPIResource{Name: "blue"},
+						EncounterDetails: []pokeapi.Encounter{
+							encounter("walk", 3, 5, 5),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tables := encounterTables(loc, "red")
+	if len(tables) != 2 || tables[0].Method != "walk" || tables[1].Method != "old-rod" {
+		t.Fatalf("Error - Unexpected tables: %+v", tables)
+	}
+	walk := tables[0].Rows
+	sortPokemonRows(walk, "rarity")
+	expected := []pokemonRow{
+		{Pokemon: "pikachu", MinLevel: 3, MaxLevel: 5, Chance: 5},
+		{Pokemon: "caterpie", MinLevel: 3, MaxLevel: 5, Chance: 50},
+	}
+	if len(walk) != len(expected) {
+		t.Fatalf("Error - Row Counts Don't Match: Actual - %d vs Expected - %d", len(walk), len(expected))
+	}
+	for i := range expected {
+		if walk[i] != expected[i] {
+			t.Errorf("Error - Rows Don't Match: Actual - %+v vs Expected - %+v", walk[i], expected[i])
+		}
+	}
+	if versions := areaVersions(loc); len(versions) != 2 || versions[0] != "red" {
+		t.Errorf("Error - Unexpected versions: %v", versions)
+	}
+}
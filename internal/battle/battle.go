@@ -0,0 +1,243 @@
+// Package battle runs turn-based fights between two pokemon using the
+// mainline damage formula.
+package battle
+
+import (
+	"fmt"
+
+	"github.com/smwalke83/pokedex/internal/typechart"
+)
+
+// RNG is the source of randomness for move choice, accuracy, critical hits
+// and damage rolls. *rand.Rand satisfies it.
+type RNG interface {
+	Intn(n int) int
+}
+
+const maxRounds = 100
+
+type Stats struct {
+	HP        int
+	Attack    int
+	Defense   int
+	SpAttack  int
+	SpDefense int
+	Speed     int
+}
+
+type Move struct {
+	Name string
+	Type string
+	// Power is 0 for status moves, which the simulator never picks.
+	Power int
+	// Accuracy is a percentage; 0 means the move never misses.
+	Accuracy int
+	Physical bool
+	Priority int
+	// Ailment is the status the move may inflict, such as "paralysis",
+	// with a percentage AilmentChance; 0 means it always does.
+	Ailment       string
+	AilmentChance int
+}
+
+// Struggle is used by a pokemon that has no damaging moves.
+var Struggle = Move{Name: "struggle", Power: 50, Physical: true}
+
+type Combatant struct {
+	Name  string
+	Level int
+	Types []string
+	Stats Stats
+	Moves []Move
+	HP    int
+	// Status is the ailment the combatant is suffering from, if any.
+	Status string
+}
+
+// statuses are the ailments that last after a battle, with how the log
+// describes a pokemon suffering from them.
+var statuses = map[string]string{
+	"paralysis": "paralyzed",
+	"sleep":     "asleep",
+	"freeze":    "frozen",
+	"burn":      "burned",
+	"poison":    "poisoned",
+}
+
+type Result struct {
+	// Winner is nil when the battle hit the round limit.
+	Winner *Combatant
+	Rounds int
+	Log    []string
+}
+
+type Battle struct {
+	rng   RNG
+	chart *typechart.Chart
+	log   []string
+}
+
+func New(rng RNG, chart *typechart.Chart) *Battle {
+	return &Battle{rng: rng, chart: chart}
+}
+
+// Run fights a against b until one faints. Both start at full HP.
+func (bt *Battle) Run(a, b *Combatant) Result {
+	bt.log = nil
+	a.HP = a.Stats.HP
+	b.HP = b.Stats.HP
+	for round := 1; round <= maxRounds; round++ {
+		bt.logf("Round %d:", round)
+		if winner := bt.round(a, b); winner != nil {
+			return bt.result(winner, round)
+		}
+	}
+	bt.logf("The battle ended in a draw after %d rounds.", maxRounds)
+	return Result{Rounds: maxRounds, Log: bt.log}
+}
+
+// Round fights a single round at the combatants' current HP, such as to
+// weaken a wild pokemon before catching it. The winner is nil unless one
+// of them fainted.
+func (bt *Battle) Round(a, b *Combatant) Result {
+	bt.log = nil
+	if winner := bt.round(a, b); winner != nil {
+		return bt.result(winner, 1)
+	}
+	return Result{Rounds: 1, Log: bt.log}
+}
+
+// round has a and b each use a move, faster first, and returns the winner
+// if either fainted.
+func (bt *Battle) round(a, b *Combatant) *Combatant {
+	moveA := bt.chooseMove(a)
+	moveB := bt.chooseMove(b)
+	first, firstMove, second, secondMove := a, moveA, b, moveB
+	if bt.goesSecond(a, moveA, b, moveB) {
+		first, firstMove, second, secondMove = b, moveB, a, moveA
+	}
+	if bt.attack(first, second, firstMove) {
+		return first
+	}
+	if bt.attack(second, first, secondMove) {
+		return second
+	}
+	return nil
+}
+
+func (bt *Battle) result(winner *Combatant, rounds int) Result {
+	bt.logf("%s wins!", winner.Name)
+	return Result{Winner: winner, Rounds: rounds, Log: bt.log}
+}
+
+func (bt *Battle) chooseMove(c *Combatant) Move {
+	damaging := make([]Move, 0, len(c.Moves))
+	for _, m := range c.Moves {
+		if m.Power > 0 {
+			damaging = append(damaging, m)
+		}
+	}
+	if len(damaging) == 0 {
+		return Struggle
+	}
+	return damaging[bt.rng.Intn(len(damaging))]
+}
+
+// goesSecond reports whether a moves after b: higher priority moves go
+// first, then the faster pokemon, with speed ties broken at random.
+func (bt *Battle) goesSecond(a *Combatant, moveA Move, b *Combatant, moveB Move) bool {
+	if moveA.Priority != moveB.Priority {
+		return moveA.Priority < moveB.Priority
+	}
+	if a.Stats.Speed != b.Stats.Speed {
+		return a.Stats.Speed < b.Stats.Speed
+	}
+	return bt.rng.Intn(2) == 1
+}
+
+// attack has attacker use move on defender and reports whether the
+// defender fainted.
+func (bt *Battle) attack(attacker, defender *Combatant, move Move) bool {
+	bt.logf("  %s used %s!", attacker.Name, move.Name)
+	if move.Accuracy > 0 && bt.rng.Intn(100) >= move.Accuracy {
+		bt.logf("  %s's attack missed!", attacker.Name)
+		return false
+	}
+	hit := bt.Damage(attacker, defender, move)
+	if hit.Effectiveness == 0 {
+		bt.logf("  It doesn't affect %s...", defender.Name)
+		return false
+	}
+	if hit.Critical {
+		bt.logf("  A critical hit!")
+	}
+	if hit.Effectiveness > 1 {
+		bt.logf("  It's super effective!")
+	} else if hit.Effectiveness < 1 {
+		bt.logf("  It's not very effective...")
+	}
+	defender.HP = max(defender.HP-hit.Damage, 0)
+	bt.logf("  %s took %d damage (%d/%d HP left).", defender.Name, hit.Damage, defender.HP, defender.Stats.HP)
+	if defender.HP == 0 {
+		bt.logf("  %s fainted!", defender.Name)
+		return true
+	}
+	bt.inflict(defender, move)
+	return false
+}
+
+// inflict gives defender the move's ailment if it rolls one and defender
+// isn't already suffering from a status.
+func (bt *Battle) inflict(defender *Combatant, move Move) {
+	described, ok := statuses[move.Ailment]
+	if !ok || defender.Status != "" {
+		return
+	}
+	if move.AilmentChance > 0 && bt.rng.Intn(100) >= move.AilmentChance {
+		return
+	}
+	defender.Status = move.Ailment
+	bt.logf("  %s is %s!", defender.Name, described)
+}
+
+type Hit struct {
+	Damage        int
+	Effectiveness float64
+	Critical      bool
+}
+
+// Damage rolls the damage move does, using the generation V+ formula:
+// ((2L/5+2) * Power * A/D / 50 + 2) * critical * random * STAB * type.
+func (bt *Battle) Damage(attacker, defender *Combatant, move Move) Hit {
+	hit := Hit{Effectiveness: 1}
+	if move.Type != "" && bt.chart != nil {
+		hit.Effectiveness = bt.chart.Multiplier(move.Type, defender.Types...)
+	}
+	if hit.Effectiveness == 0 {
+		return hit
+	}
+	atk, def := attacker.Stats.SpAttack, defender.Stats.SpDefense
+	if move.Physical {
+		atk, def = attacker.Stats.Attack, defender.Stats.Defense
+	}
+	base := (2*attacker.Level/5+2)*move.Power*max(atk, 1)/max(def, 1)/50 + 2
+	mod := 1.0
+	if bt.rng.Intn(24) == 0 {
+		hit.Critical = true
+		mod *= 1.5
+	}
+	mod *= float64(85+bt.rng.Intn(16)) / 100
+	for _, t := range attacker.Types {
+		if t == move.Type {
+			mod *= 1.5
+			break
+		}
+	}
+	mod *= hit.Effectiveness
+	hit.Damage = max(int(float64(base)*mod), 1)
+	return hit
+}
+
+func (bt *Battle) logf(format string, args ...any) {
+	bt.log = append(bt.log, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,138 @@
+package battle
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/typechart"
+)
+
+// fixedRNG returns the same value for every roll, clamped to the range asked
+// for.
+type fixedRNG int
+
+func (f fixedRNG) Intn(n int) int {
+	return min(int(f), n-1)
+}
+
+func testChart() *typechart.Chart {
+	return typechart.New([]pokeapi.Type{
+		{Name: "electric", DamageRelations: pokeapi.TypeRelations{
+			NoDamageTo:     []pokeapi.NamedAPIResource{{Name: "ground"}},
+			DoubleDamageTo: []pokeapi.NamedAPIResource{{Name: "water"}},
+		}},
+		{Name: "water"},
+		{Name: "ground"},
+		{Name: "normal"},
+	}, 0)
+}
+
+func pikachu() *Combatant {
+	return &Combatant{
+		Name:  "pikachu",
+		Level: 50,
+		Types: []string{"electric"},
+		Stats: Stats{HP: 110, Attack: 75, Defense: 60, SpAttack: 70, SpDefense: 70, Speed: 110},
+		Moves: []Move{{Name: "thunderbolt", Type: "electric", Power: 90, Accuracy: 100}},
+	}
+}
+
+func squirtle() *Combatant {
+	return &Combatant{
+		Name:  "squirtle",
+		Level: 50,
+		Types: []string{"water"},
+		Stats: Stats{HP: 104, Attack: 68, Defense: 85, SpAttack: 70, SpDefense: 84, Speed: 63},
+		Moves: []Move{{Name: "tackle", Type: "normal", Power: 40, Accuracy: 100, Physical: true}},
+	}
+}
+
+func TestDamage(t *testing.T) {
+	// A roll of 15 means no critical hit and the maximum random factor.
+	bt := New(fixedRNG(15), testChart())
+	hit := bt.Damage(pikachu(), squirtle(), pikachu().Moves[0])
+	// base = 22*90*70/84/50+2 = 35, then *1.5 STAB *2 super effective.
+	if hit.Damage != 105 || hit.Effectiveness != 2 || hit.Critical {
+		t.Errorf("Actual - %+v vs Expected - 105 damage, 2x, no critical", hit)
+	}
+
+	crit := New(fixedRNG(0), testChart()).Damage(pikachu(), squirtle(), pikachu().Moves[0])
+	if !crit.Critical {
+		t.Errorf("expected a critical hit with a roll of 0")
+	}
+}
+
+func TestDamageImmune(t *testing.T) {
+	ground := squirtle()
+	ground.Types = []string{"ground"}
+	hit := New(fixedRNG(15), testChart()).Damage(pikachu(), ground, pikachu().Moves[0])
+	if hit.Damage != 0 || hit.Effectiveness != 0 {
+		t.Errorf("expected no damage against a ground type, got %+v", hit)
+	}
+}
+
+func TestFasterPokemonMovesFirst(t *testing.T) {
+	res := New(fixedRNG(15), testChart()).Run(pikachu(), squirtle())
+	if res.Winner == nil || res.Winner.Name != "pikachu" {
+		t.Fatalf("expected pikachu to win, got %+v", res.Winner)
+	}
+	if res.Rounds != 1 {
+		t.Errorf("expected a one-round knockout, got %d rounds", res.Rounds)
+	}
+	if !strings.Contains(res.Log[1], "pikachu used thunderbolt") {
+		t.Errorf("expected pikachu to move first, log: %v", res.Log)
+	}
+}
+
+func TestSeededBattleIsDeterministic(t *testing.T) {
+	run := func() []string {
+		a, b := pikachu(), squirtle()
+		a.Moves = append(a.Moves, Move{Name: "quick-attack", Type: "normal", Power: 40, Accuracy: 100, Physical: true, Priority: 1})
+		b.Stats.HP = 300
+		return New(rand.New(rand.NewSource(42)), testChart()).Run(a, b).Log
+	}
+	first, second := run(), run()
+	if strings.Join(first, "\n") != strings.Join(second, "\n") {
+		t.Errorf("expected identical logs for the same seed")
+	}
+}
+
+func TestStruggleWithoutDamagingMoves(t *testing.T) {
+	c := pikachu()
+	c.Moves = []Move{{Name: "growl", Type: "normal"}}
+	if m := New(fixedRNG(0), testChart()).chooseMove(c); m.Name != "struggle" {
+		t.Errorf("expected struggle, got %s", m.Name)
+	}
+}
+
+func TestRoundKeepsHP(t *testing.T) {
+	a, b := pikachu(), squirtle()
+	a.HP, b.HP = a.Stats.HP, 200
+	b.Stats.HP = 300
+	result := New(fixedRNG(5), testChart()).Round(a, b)
+	if result.Winner != nil {
+		t.Fatalf("expected nobody to faint, got %s", result.Winner.Name)
+	}
+	if b.HP >= 200 || a.HP >= a.Stats.HP {
+		t.Errorf("Error - HP after a round. Actual - %d, %d", a.HP, b.HP)
+	}
+}
+
+func TestAilment(t *testing.T) {
+	a, b := pikachu(), squirtle()
+	a.Moves[0].Ailment, a.Moves[0].AilmentChance = "paralysis", 10
+	b.Stats.HP = 300
+	b.HP = b.Stats.HP
+	bt := New(fixedRNG(5), testChart())
+	bt.attack(a, b, a.Moves[0])
+	if b.Status != "paralysis" {
+		t.Errorf("Error - status. Actual - %q vs Expected - paralysis", b.Status)
+	}
+	// A pokemon only suffers from one status at a time.
+	bt.attack(a, b, Move{Name: "ember", Power: 40, Ailment: "burn"})
+	if b.Status != "paralysis" {
+		t.Errorf("Error - status after a second ailment. Actual - %q vs Expected - paralysis", b.Status)
+	}
+}
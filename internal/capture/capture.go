@@ -0,0 +1,108 @@
+// Package capture implements the generation III/IV catch formula.
+package capture
+
+import "math"
+
+// RNG is the source of randomness used for shake checks. *rand.Rand
+// satisfies it.
+type RNG interface {
+	Intn(n int) int
+}
+
+type Status int
+
+const (
+	StatusNone Status = iota
+	StatusSleep
+	StatusFreeze
+	StatusParalysis
+	StatusPoison
+	StatusBurn
+)
+
+// StatusOf returns the status for one of PokeAPI's ailment names, such as
+// "paralysis", or StatusNone.
+func StatusOf(ailment string) Status {
+	switch ailment {
+	case "sleep":
+		return StatusSleep
+	case "freeze":
+		return StatusFreeze
+	case "paralysis":
+		return StatusParalysis
+	case "poison":
+		return StatusPoison
+	case "burn":
+		return StatusBurn
+	}
+	return StatusNone
+}
+
+func (s Status) Bonus() float64 {
+	switch s {
+	case StatusSleep, StatusFreeze:
+		return 2
+	case StatusParalysis, StatusPoison, StatusBurn:
+		return 1.5
+	}
+	return 1
+}
+
+type Params struct {
+	CaptureRate int
+	MaxHP       int
+	CurrentHP   int
+	// Ball is the ball's catch modifier, 1 for a plain Poke Ball. A Master
+	// Ball is modelled as 255, which always catches.
+	Ball   float64
+	Status Status
+}
+
+type Result struct {
+	Caught bool
+	// Shakes is how many times the ball wobbled before the pokemon broke
+	// free, or 3 when it was caught.
+	Shakes int
+}
+
+// ModifiedRate returns the modified catch rate "a". Values of 255 or more
+// are a guaranteed catch.
+func ModifiedRate(p Params) float64 {
+	maxHP := max(p.MaxHP, 1)
+	curHP := min(max(p.CurrentHP, 1), maxHP)
+	ball := p.Ball
+	if ball <= 0 {
+		ball = 1
+	}
+	a := math.Floor(float64(3*maxHP-2*curHP) * float64(p.CaptureRate) * ball / float64(3*maxHP))
+	return max(a*p.Status.Bonus(), 1)
+}
+
+// shakeThreshold returns "b"; each of the four shake checks passes when a
+// random number in [0, 65535] is below it.
+func shakeThreshold(a float64) int {
+	return int(1048560 / math.Sqrt(math.Sqrt(16711680/a)))
+}
+
+// Probability returns the chance that a single throw catches the pokemon.
+func Probability(p Params) float64 {
+	a := ModifiedRate(p)
+	if a >= 255 {
+		return 1
+	}
+	return math.Pow(float64(shakeThreshold(a))/65536, 4)
+}
+
+func Attempt(rng RNG, p Params) Result {
+	a := ModifiedRate(p)
+	if a >= 255 {
+		return Result{Caught: true, Shakes: 3}
+	}
+	b := shakeThreshold(a)
+	for i := 0; i < 4; i++ {
+		if rng.Intn(65536) >= b {
+			return Result{Shakes: min(i, 3)}
+		}
+	}
+	return Result{Caught: true, Shakes: 3}
+}
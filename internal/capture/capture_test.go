@@ -0,0 +1,103 @@
+package capture
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+type fixedRNG []int
+
+func (f *fixedRNG) Intn(n int) int {
+	v := (*f)[0]
+	*f = (*f)[1:]
+	return v
+}
+
+func TestModifiedRate(t *testing.T) {
+	cases := []struct {
+		name     string
+		params   Params
+		expected float64
+	}{
+		{
+			name:     "full hp poke ball",
+			params:   Params{CaptureRate: 45, MaxHP: 100, CurrentHP: 100, Ball: 1},
+			expected: 15,
+		},
+		{
+			name:     "one hp great ball asleep",
+			params:   Params{CaptureRate: 45, MaxHP: 100, CurrentHP: 1, Ball: 1.5, Status: StatusSleep},
+			expected: 134,
+		},
+		{
+			name:     "paralyzed ultra ball",
+			params:   Params{CaptureRate: 190, MaxHP: 30, CurrentHP: 30, Ball: 2, Status: StatusParalysis},
+			expected: 189,
+		},
+	}
+	for _, c := range cases {
+		actual := ModifiedRate(c.params)
+		if actual != c.expected {
+			t.Errorf("%s: Actual - %v vs Expected - %v", c.name, actual, c.expected)
+		}
+	}
+}
+
+func TestMasterBallAlwaysCatches(t *testing.T) {
+	p := Params{CaptureRate: 3, MaxHP: 100, CurrentHP: 100, Ball: 255}
+	if Probability(p) != 1 {
+		t.Errorf("expected a guaranteed catch, got %v", Probability(p))
+	}
+	res := Attempt(&fixedRNG{}, p)
+	if !res.Caught {
+		t.Errorf("expected the pokemon to be caught")
+	}
+}
+
+func TestAttemptShakes(t *testing.T) {
+	p := Params{CaptureRate: 45, MaxHP: 100, CurrentHP: 100, Ball: 1}
+	b := shakeThreshold(ModifiedRate(p))
+	rng := fixedRNG{0, 0, b, 0}
+	res := Attempt(&rng, p)
+	if res.Caught || res.Shakes != 2 {
+		t.Errorf("expected 2 shakes and an escape, got %+v", res)
+	}
+	rng = fixedRNG{0, 0, 0, 0}
+	res = Attempt(&rng, p)
+	if !res.Caught || res.Shakes != 3 {
+		t.Errorf("expected a catch, got %+v", res)
+	}
+}
+
+func TestProbabilityMatchesAttempts(t *testing.T) {
+	p := Params{CaptureRate: 45, MaxHP: 100, CurrentHP: 50, Ball: 1.5}
+	rng := rand.New(rand.NewSource(1))
+	const trials = 20000
+	caught := 0
+	for i := 0; i < trials; i++ {
+		if Attempt(rng, p).Caught {
+			caught++
+		}
+	}
+	actual := float64(caught) / trials
+	expected := Probability(p)
+	if math.Abs(actual-expected) > 0.02 {
+		t.Errorf("catch rate: Actual - %.3f vs Expected - %.3f", actual, expected)
+	}
+}
+
+func TestStatusOf(t *testing.T) {
+	cases := map[string]Status{
+		"sleep":     StatusSleep,
+		"paralysis": StatusParalysis,
+		"burn":      StatusBurn,
+		"confusion": StatusNone,
+		"":          StatusNone,
+	}
+	for ailment, expected := range cases {
+		if actual := StatusOf(ailment); actual != expected {
+			t.Errorf("Error - %q. Actual - %v vs Expected - %v", ailment, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Package command defines the commands the REPL runs and the registry they
+// are looked up in. A package adds commands by calling Register from an
+// init function; the REPL picks up everything registered in Default, so a
+// command package only needs importing, as internal/shop is. Commands get
+// what they depend on from the session passed to Run.
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/smwalke83/pokedex/internal/session"
+)
+
+// Arg describes a positional argument.
+type Arg struct {
+	Name        string
+	Description string
+	Required    bool
+	// Variadic marks the last argument as taking the rest of the line.
+	Variadic bool
+}
+
+// Flag describes a --flag.
+type Flag struct {
+	Name        string
+	Description string
+	// Value is set for flags written --name value or --name=value, and
+	// unset for flags that are just switched on with --name.
+	Value bool
+}
+
+// Category groups related commands in help.
+type Category string
+
+const (
+	Navigation Category = "navigation"
+	Collection Category = "collection"
+	Battle     Category = "battle"
+	System     Category = "system"
+)
+
+// Categories lists every category in the order help shows them.
+var Categories = []Category{Navigation, Collection, Battle, System}
+
+// Spec is the metadata a command declares about itself.
+type Spec struct {
+	Name     string
+	Aliases  []string
+	Category Category
+	// Usage shows how to call the command, e.g.
+	// "explore <area> [--details] [--version red]".
+	Usage       string
+	Description string
+	Args        []Arg
+	Flags       []Flag
+	// Examples are complete command lines shown by help <command>.
+	Examples []string
+}
+
+func (s Spec) flag(name string) (Flag, bool) {
+	for _, f := range s.Flags {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Flag{}, false
+}
+
+// RunFunc runs a command with its parsed arguments and flags. Everything a
+// command depends on - the API client, the random number generator and the
+// trainer's state - comes from the session.
+type RunFunc func(ctx context.Context, s *session.Session, args []string, flags map[string]string) error
+
+type Command interface {
+	Spec() Spec
+	Run(ctx context.Context, s *session.Session, args []string, flags map[string]string) error
+}
+
+// New makes a Command from a spec and the function that runs it.
+func New(spec Spec, run RunFunc) Command {
+	return funcCommand{spec: spec, run: run}
+}
+
+type funcCommand struct {
+	spec Spec
+	run  RunFunc
+}
+
+func (c funcCommand) Spec() Spec {
+	return c.spec
+}
+
+func (c funcCommand) Run(ctx context.Context, s *session.Session, args []string, flags map[string]string) error {
+	return c.run(ctx, s, args, flags)
+}
+
+// Parse separates words into positional arguments and the --flags declared
+// by spec. Flags are written --name=value or --name value, or just --name
+// for flags that take no value. A bare -- ends flag parsing. It is an error
+// to pass more arguments than the spec declares or to leave out a required
+// one, so commands can rely on their required arguments being there.
+func Parse(spec Spec, words []string) ([]string, map[string]string, error) {
+	args := []string{}
+	flags := make(map[string]string)
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if word == "--" {
+			args = append(args, words[i+1:]...)
+			break
+		}
+		if !strings.HasPrefix(word, "--") {
+			args = append(args, word)
+			continue
+		}
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(word, "--"), "=")
+		f, ok := spec.flag(name)
+		if !ok {
+			return nil, nil, fmt.Errorf("Invalid command - %s does not accept the --%s flag.", spec.Name, name)
+		}
+		if f.Value && !hasValue {
+			if i+1 >= len(words) {
+				return nil, nil, fmt.Errorf("Invalid command - the --%s flag needs a value.", name)
+			}
+			value = words[i+1]
+			i++
+		} else if !f.Value {
+			if hasValue {
+				return nil, nil, fmt.Errorf("Invalid command - the --%s flag does not take a value.", name)
+			}
+			value = "true"
+		}
+		flags[name] = value
+	}
+	variadic := len(spec.Args) > 0 && spec.Args[len(spec.Args)-1].Variadic
+	if !variadic && len(args) > len(spec.Args) {
+		return nil, nil, fmt.Errorf("Invalid command - too many parameters. Usage: %s", spec.Usage)
+	}
+	for i, arg := range spec.Args {
+		if arg.Required && i >= len(args) {
+			return nil, nil, fmt.Errorf("Invalid command - missing the %s parameter. Usage: %s", arg.Name, spec.Usage)
+		}
+	}
+	return args, flags, nil
+}
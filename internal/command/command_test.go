@@ -0,0 +1,96 @@
+package command
+
+import (
+	"strings"
+	"testing"
+)
+
+var exploreSpec = Spec{
+	Name:  "explore",
+	Usage: "explore <area> [--details] [--version red]",
+	Args:  []Arg{{Name: "area", Required: true}},
+	Flags: []Flag{
+		{Name: "version", Value: true},
+		{Name: "details"},
+	},
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		input []string
+		args  []string
+		flags map[string]string
+	}{
+		{
+			input: []string{"canalave-city-area", "--version", "diamond"},
+			args:  []string{"canalave-city-area"},
+			flags: map[string]string{"version": "diamond"},
+		},
+		{
+			input: []string{"--version=pearl", "--details", "canalave-city-area"},
+			args:  []string{"canalave-city-area"},
+			flags: map[string]string{"version": "pearl", "details": "true"},
+		},
+		{
+			input: []string{"--", "--details"},
+			args:  []string{"--details"},
+			flags: map[string]string{},
+		},
+	}
+	for _, c := range cases {
+		args, flags, err := Parse(exploreSpec, c.input)
+		if err != nil {
+			t.Errorf("Error - Unexpected error for %v: %v", c.input, err)
+			continue
+		}
+		if strings.Join(args, " ") != strings.Join(c.args, " ") {
+			t.Errorf("Error - Args Don't Match: Actual - %v vs Expected - %v", args, c.args)
+		}
+		if len(flags) != len(c.flags) {
+			t.Errorf("Error - Flags Don't Match: Actual - %v vs Expected - %v", flags, c.flags)
+		}
+		for name, value := range c.flags {
+			if flags[name] != value {
+				t.Errorf("Error - Flags Don't Match: Actual - %v vs Expected - %v", flags, c.flags)
+			}
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	inputs := [][]string{
+		{"area", "--unknown"},
+		{"area", "--version"},
+		{"area", "--details=yes"},
+		{"area", "another-area"},
+		{},
+		{"--details"},
+	}
+	for _, input := range inputs {
+		_, _, err := Parse(exploreSpec, input)
+		if err == nil {
+			t.Errorf("Error - Expected an error for %v", input)
+		}
+	}
+}
+
+func TestParseVariadic(t *testing.T) {
+	spec := Spec{Name: "nickname", Args: []Arg{{Name: "id"}, {Name: "name", Variadic: true}}}
+	args, _, err := Parse(spec, []string{"1", "sir", "sparks"})
+	if err != nil || len(args) != 3 {
+		t.Errorf("Error - Variadic args. Actual - %v, %v", args, err)
+	}
+}
+
+func TestParseMissingArgs(t *testing.T) {
+	spec := Spec{
+		Name:  "matchup",
+		Usage: "matchup <attacker> <defender>",
+		Args:  []Arg{{Name: "attacker", Required: true}, {Name: "defender", Required: true}},
+	}
+	_, _, err := Parse(spec, []string{"pikachu"})
+	expected := "Invalid command - missing the defender parameter. Usage: matchup <attacker> <defender>"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Error - Missing args. Actual - %v vs Expected - %s", err, expected)
+	}
+}
@@ -0,0 +1,51 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// Help renders the detailed help for a command: its description, usage,
+// aliases, arguments, flags and examples.
+func (s Spec) Help() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s - %s\n", s.Name, s.Description)
+	fmt.Fprintf(&b, "Usage: %s\n", s.Usage)
+	if len(s.Aliases) > 0 {
+		fmt.Fprintf(&b, "Aliases: %s\n", strings.Join(s.Aliases, ", "))
+	}
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	if len(s.Args) > 0 {
+		fmt.Fprintln(w, "Arguments:")
+		for _, arg := range s.Args {
+			description := arg.Description
+			if arg.Required {
+				description += " (required)"
+			}
+			name := arg.Name
+			if arg.Variadic {
+				name += "..."
+			}
+			fmt.Fprintf(w, "  %s\t%s\n", name, description)
+		}
+	}
+	if len(s.Flags) > 0 {
+		fmt.Fprintln(w, "Flags:")
+		for _, f := range s.Flags {
+			name := "--" + f.Name
+			if f.Value {
+				name += " <value>"
+			}
+			fmt.Fprintf(w, "  %s\t%s\n", name, f.Description)
+		}
+	}
+	w.Flush()
+	if len(s.Examples) > 0 {
+		fmt.Fprintln(&b, "Examples:")
+		for _, example := range s.Examples {
+			fmt.Fprintf(&b, "  %s\n", example)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,38 @@
+package command
+
+import "testing"
+
+func TestHelp(t *testing.T) {
+	spec := Spec{
+		Name:        "box",
+		Aliases:     []string{"pc"},
+		Usage:       "box [list|release <id>] [--all]",
+		Description: "List your boxed Pokemon",
+		Args: []Arg{
+			{Name: "action", Description: "list or release", Required: true},
+			{Name: "rest", Description: "everything else", Variadic: true},
+		},
+		Flags:    []Flag{{Name: "all", Description: "show everything"}, {Name: "gen", Description: "generation", Value: true}},
+		Examples: []string{"box release 3"},
+	}
+	expected := `box - List your boxed Pokemon
+Usage: box [list|release <id>] [--all]
+Aliases: pc
+Arguments:
+  action   list or release (required)
+  rest...  everything else
+Flags:
+  --all          show everything
+  --gen <value>  generation
+Examples:
+  box release 3
+`
+	if actual := spec.Help(); actual != expected {
+		t.Errorf("Error - Help Doesn't Match: Actual - %q vs Expected - %q", actual, expected)
+	}
+
+	expected = "exit - Exit the Pokedex\nUsage: exit\n"
+	if actual := (Spec{Name: "exit", Usage: "exit", Description: "Exit the Pokedex"}).Help(); actual != expected {
+		t.Errorf("Error - Help Doesn't Match: Actual - %q vs Expected - %q", actual, expected)
+	}
+}
@@ -0,0 +1,70 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Registry looks commands up by name or alias.
+type Registry struct {
+	commands map[string]Command
+	// names maps every name and alias to the command's name.
+	names map[string]string
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		commands: make(map[string]Command),
+		names:    make(map[string]string),
+	}
+}
+
+// Default is the registry the REPL uses.
+var Default = NewRegistry()
+
+// Register adds cmd to Default. It panics if the command's name or one of
+// its aliases is already taken, since that is a programming error.
+func Register(cmd Command) {
+	if err := Default.Register(cmd); err != nil {
+		panic(err)
+	}
+}
+
+// Register adds cmd, failing if its name or one of its aliases is already
+// taken.
+func (r *Registry) Register(cmd Command) error {
+	spec := cmd.Spec()
+	if spec.Name == "" {
+		return errors.New("command has no name")
+	}
+	for _, name := range append([]string{spec.Name}, spec.Aliases...) {
+		if taken, ok := r.names[name]; ok {
+			return fmt.Errorf("command %s: %q is already used by %s", spec.Name, name, taken)
+		}
+	}
+	r.commands[spec.Name] = cmd
+	r.names[spec.Name] = spec.Name
+	for _, alias := range spec.Aliases {
+		r.names[alias] = spec.Name
+	}
+	return nil
+}
+
+// Lookup finds a command by its name or one of its aliases.
+func (r *Registry) Lookup(name string) (Command, bool) {
+	cmd, ok := r.commands[r.names[name]]
+	return cmd, ok
+}
+
+// Commands returns every registered command, sorted by name.
+func (r *Registry) Commands() []Command {
+	cmds := make([]Command, 0, len(r.commands))
+	for _, cmd := range r.commands {
+		cmds = append(cmds, cmd)
+	}
+	sort.Slice(cmds, func(i, j int) bool {
+		return cmds[i].Spec().Name < cmds[j].Spec().Name
+	})
+	return cmds
+}
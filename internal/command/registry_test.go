@@ -0,0 +1,59 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smwalke83/pokedex/internal/session"
+)
+
+func noop(context.Context, *session.Session, []string, map[string]string) error {
+	return nil
+}
+
+func TestRegistryLookup(t *testing.T) {
+	r := NewRegistry()
+	for _, spec := range []Spec{
+		{Name: "pokedex", Aliases: []string{"dex"}},
+		{Name: "exit", Aliases: []string{"quit"}},
+		{Name: "bag"},
+	} {
+		if err := r.Register(New(spec, noop)); err != nil {
+			t.Fatalf("unexpected error registering %s: %v", spec.Name, err)
+		}
+	}
+	for name, expected := range map[string]string{"pokedex": "pokedex", "dex": "pokedex", "quit": "exit", "bag": "bag"} {
+		cmd, ok := r.Lookup(name)
+		if !ok || cmd.Spec().Name != expected {
+			t.Errorf("Error - Lookup %q. Actual - %v vs Expected - %s", name, cmd, expected)
+		}
+	}
+	if _, ok := r.Lookup("catch"); ok {
+		t.Errorf("expected catch not to be registered")
+	}
+
+	names := []string{}
+	for _, cmd := range r.Commands() {
+		names = append(names, cmd.Spec().Name)
+	}
+	if len(names) != 3 || names[0] != "bag" || names[1] != "exit" || names[2] != "pokedex" {
+		t.Errorf("Error - Commands. Actual - %v vs Expected - [bag exit pokedex]", names)
+	}
+}
+
+func TestRegistryRejectsDuplicates(t *testing.T) {
+	r := NewRegistry()
+	if err := r.Register(New(Spec{Name: "pokedex", Aliases: []string{"dex"}}, noop)); err != nil {
+		t.Fatal(err)
+	}
+	for _, spec := range []Spec{
+		{Name: "pokedex"},
+		{Name: "dex"},
+		{Name: "index", Aliases: []string{"dex"}},
+		{},
+	} {
+		if err := r.Register(New(spec, noop)); err == nil {
+			t.Errorf("expected an error registering %+v", spec)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Package fuzzy matches names the way a forgiving prompt should: exactly,
+// by a prefix only one name starts with, or failing that by suggesting the
+// names closest to a typo.
+package fuzzy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxSuggestions is the most names an error suggests.
+const MaxSuggestions = 3
+
+// Distance returns the Levenshtein distance between a and b: the number of
+// single-rune insertions, deletions and substitutions that turn a into b.
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// Index is a set of names of one kind, such as every pokemon or every
+// command.
+type Index struct {
+	kind  string
+	names []string
+}
+
+// NewIndex returns an index of names, described as kind in errors.
+func NewIndex(kind string, names []string) *Index {
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+	return &Index{kind: kind, names: sorted}
+}
+
+func (ix *Index) Names() []string {
+	return ix.names
+}
+
+// Lookup returns name if it's in the index, or the one name that starts
+// with it. Otherwise it returns an *AmbiguousError listing the names that
+// start with it, or a *NotFoundError suggesting the closest names.
+func (ix *Index) Lookup(name string) (string, error) {
+	i := sort.SearchStrings(ix.names, name)
+	if i < len(ix.names) && ix.names[i] == name {
+		return name, nil
+	}
+	matches := []string{}
+	for ; i < len(ix.names) && strings.HasPrefix(ix.names[i], name); i++ {
+		matches = append(matches, ix.names[i])
+	}
+	switch {
+	case len(matches) == 1:
+		return matches[0], nil
+	case len(matches) > 1 && name != "":
+		return "", &AmbiguousError{Kind: ix.kind, Name: name, Matches: matches}
+	}
+	return "", &NotFoundError{Kind: ix.kind, Name: name, Suggestions: ix.Suggest(name)}
+}
+
+// Suggest returns up to MaxSuggestions names within a few edits of name,
+// closest first. Longer names are allowed more edits.
+func (ix *Index) Suggest(name string) []string {
+	limit := max(2, utf8.RuneCountInString(name)/3)
+	type candidate struct {
+		name     string
+		distance int
+	}
+	candidates := []candidate{}
+	for _, n := range ix.names {
+		if d := Distance(name, n); d <= limit {
+			candidates = append(candidates, candidate{name: n, distance: d})
+		}
+	}
+	// The names are already sorted, so a stable sort keeps ties in order.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+	suggestions := []string{}
+	for i := 0; i < len(candidates) && i < MaxSuggestions; i++ {
+		suggestions = append(suggestions, candidates[i].name)
+	}
+	return suggestions
+}
+
+// NotFoundError is returned for a name that isn't in an index.
+type NotFoundError struct {
+	Kind        string
+	Name        string
+	Suggestions []string
+}
+
+func (e *NotFoundError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("Unknown %s %q.", e.Kind, e.Name)
+	}
+	return fmt.Sprintf("Unknown %s %q - did you mean %s?", e.Kind, e.Name, orList(e.Suggestions))
+}
+
+// AmbiguousError is returned for a prefix of several names.
+type AmbiguousError struct {
+	Kind    string
+	Name    string
+	Matches []string
+}
+
+func (e *AmbiguousError) Error() string {
+	matches := e.Matches
+	if len(matches) > 5 {
+		matches = append(matches[:5:5], fmt.Sprintf("%d more", len(e.Matches)-5))
+	}
+	return fmt.Sprintf("%q could be more than one %s: %s.", e.Name, e.Kind, orList(matches))
+}
+
+// orList joins words as "a", "a or b" or "a, b or c".
+func orList(words []string) string {
+	if len(words) == 1 {
+		return words[0]
+	}
+	return strings.Join(words[:len(words)-1], ", ") + " or " + words[len(words)-1]
+}
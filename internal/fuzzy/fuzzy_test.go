@@ -0,0 +1,76 @@
+package fuzzy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "pikachu", b: "pikachu", expected: 0},
+		{a: "pikahcu", b: "pikachu", expected: 2},
+		{a: "pikachu", b: "pikach", expected: 1},
+		{a: "", b: "bag", expected: 3},
+		{a: "flabébé", b: "flabebe", expected: 2},
+		{a: "kitten", b: "sitting", expected: 3},
+	}
+	for _, c := range cases {
+		if actual := Distance(c.a, c.b); actual != c.expected {
+			t.Errorf("Error - Distance(%q, %q): Actual - %d vs Expected - %d", c.a, c.b, actual, c.expected)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	ix := NewIndex("pokemon", []string{"pikachu", "pichu", "raichu", "pidgey", "pidgeotto", "mr-mime", "mr-rime"})
+	cases := []struct {
+		name     string
+		expected string
+	}{
+		{name: "pikachu", expected: "pikachu"},
+		{name: "pik", expected: "pikachu"},
+		{name: "pidgey", expected: "pidgey"},
+		{name: "pidgeo", expected: "pidgeotto"},
+	}
+	for _, c := range cases {
+		actual, err := ix.Lookup(c.name)
+		if err != nil || actual != c.expected {
+			t.Errorf("Error - Lookup(%q): Actual - %q, %v vs Expected - %q", c.name, actual, err, c.expected)
+		}
+	}
+
+	_, err := ix.Lookup("pikahcu")
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) || strings.Join(notFound.Suggestions, " ") != "pikachu" {
+		t.Errorf("Error - Lookup(pikahcu): Actual - %v vs Expected - a suggestion of pikachu", err)
+	}
+	if err.Error() != `Unknown pokemon "pikahcu" - did you mean pikachu?` {
+		t.Errorf("Error - Message Doesn't Match: Actual - %q", err)
+	}
+
+	_, err = ix.Lookup("mr")
+	var ambiguous *AmbiguousError
+	if !errors.As(err, &ambiguous) || len(ambiguous.Matches) != 2 {
+		t.Errorf("Error - Lookup(mr): Actual - %v vs Expected - mr-mime or mr-rime", err)
+	}
+	if err.Error() != `"mr" could be more than one pokemon: mr-mime or mr-rime.` {
+		t.Errorf("Error - Message Doesn't Match: Actual - %q", err)
+	}
+
+	_, err = ix.Lookup("zzzzzzzz")
+	if !errors.As(err, &notFound) || len(notFound.Suggestions) != 0 || err.Error() != `Unknown pokemon "zzzzzzzz".` {
+		t.Errorf("Error - Lookup(zzzzzzzz): Actual - %v", err)
+	}
+}
+
+func TestSuggestOrdersByDistance(t *testing.T) {
+	ix := NewIndex("command", []string{"map", "mapb", "bag", "help", "exit"})
+	suggestions := ix.Suggest("mao")
+	if strings.Join(suggestions, " ") != "map bag mapb" {
+		t.Errorf("Error - Suggestions Don't Match: Actual - %v vs Expected - [map bag mapb]", suggestions)
+	}
+}
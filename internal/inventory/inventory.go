@@ -0,0 +1,71 @@
+// Package inventory tracks the items a trainer is carrying.
+package inventory
+
+import (
+	"fmt"
+	"sort"
+)
+
+const DefaultBall = "poke-ball"
+
+var ballModifiers = map[string]float64{
+	"poke-ball":   1,
+	"great-ball":  1.5,
+	"ultra-ball":  2,
+	"master-ball": 255,
+}
+
+// BallModifier returns the catch modifier of a ball, or false if the item is
+// not a ball.
+func BallModifier(item string) (float64, bool) {
+	mod, ok := ballModifiers[item]
+	return mod, ok
+}
+
+// Bag maps item names, as used by PokeAPI's /item endpoint, to counts.
+type Bag map[string]int
+
+func StarterBag() Bag {
+	return Bag{
+		"poke-ball":    10,
+		"great-ball":   5,
+		"ultra-ball":   3,
+		"master-ball":  1,
+		"potion":       3,
+		"super-potion": 1,
+		"oran-berry":   2,
+		"sitrus-berry": 1,
+	}
+}
+
+func (b Bag) Count(item string) int {
+	return b[item]
+}
+
+func (b Bag) Add(item string, n int) {
+	b[item] += n
+}
+
+// Use removes one of item from the bag.
+func (b Bag) Use(item string) error {
+	if b[item] <= 0 {
+		return fmt.Errorf("You don't have any %s left.", item)
+	}
+	b[item]--
+	if b[item] == 0 {
+		delete(b, item)
+	}
+	return nil
+}
+
+// Items returns the names of the items in the bag in alphabetical order.
+func (b Bag) Items() []string {
+	items := make([]string, 0, len(b))
+	for item, n := range b {
+		if n > 0 {
+			items = append(items, item)
+		}
+	}
+	sort.Strings(items)
+	return items
+}
@@ -0,0 +1,44 @@
+package inventory
+
+import (
+	"testing"
+)
+
+func TestUse(t *testing.T) {
+	bag := Bag{"great-ball": 1}
+	if err := bag.Use("great-ball"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bag.Count("great-ball") != 0 {
+		t.Errorf("expected no great-balls left, got %d", bag.Count("great-ball"))
+	}
+	if err := bag.Use("great-ball"); err == nil {
+		t.Errorf("expected an error using an empty item")
+	}
+	if len(bag.Items()) != 0 {
+		t.Errorf("expected an empty bag, got %v", bag.Items())
+	}
+}
+
+func TestItemsSorted(t *testing.T) {
+	bag := Bag{"ultra-ball": 1, "potion": 2, "great-ball": 3}
+	expected := []string{"great-ball", "potion", "ultra-ball"}
+	actual := bag.Items()
+	if len(actual) != len(expected) {
+		t.Fatalf("Actual - %v vs Expected - %v", actual, expected)
+	}
+	for i := range actual {
+		if actual[i] != expected[i] {
+			t.Errorf("Actual - %v vs Expected - %v", actual, expected)
+		}
+	}
+}
+
+func TestBallModifier(t *testing.T) {
+	if mod, ok := BallModifier("great-ball"); !ok || mod != 1.5 {
+		t.Errorf("expected great-ball modifier 1.5, got %v %v", mod, ok)
+	}
+	if _, ok := BallModifier("potion"); ok {
+		t.Errorf("expected potion not to be a ball")
+	}
+}
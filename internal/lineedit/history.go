@@ -0,0 +1,104 @@
+package lineedit
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultHistorySize is how many lines a history keeps by default.
+const DefaultHistorySize = 1000
+
+// History is the list of lines entered, oldest first. A history loaded from
+// a file appends every new line to it, so it survives restarts and crashes.
+type History struct {
+	entries []string
+	max     int
+	path    string
+}
+
+// NewHistory returns an empty in-memory history of at most max lines.
+func NewHistory(max int) *History {
+	return &History{max: max}
+}
+
+// LoadHistory reads the history in path, keeping the newest max lines, and
+// saves lines added from now on to it. A missing file is an empty history.
+func LoadHistory(path string, max int) (*History, error) {
+	h := &History{max: max, path: path}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	scan := bufio.NewScanner(f)
+	for scan.Scan() {
+		if line := scan.Text(); strings.TrimSpace(line) != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+	if err := scan.Err(); err != nil {
+		return nil, err
+	}
+	if max > 0 && len(h.entries) > max {
+		h.entries = h.entries[len(h.entries)-max:]
+		// Rewrite the file so it doesn't grow forever.
+		data := strings.Join(h.entries, "\n") + "\n"
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+func (h *History) Len() int {
+	return len(h.entries)
+}
+
+// At returns entry i, where 0 is the oldest.
+func (h *History) At(i int) string {
+	return h.entries[i]
+}
+
+// Add records line unless it's blank or repeats the newest entry.
+func (h *History) Add(line string) error {
+	if strings.TrimSpace(line) == "" || (len(h.entries) > 0 && h.entries[len(h.entries)-1] == line) {
+		return nil
+	}
+	h.entries = append(h.entries, line)
+	if h.max > 0 && len(h.entries) > h.max {
+		h.entries = h.entries[len(h.entries)-h.max:]
+	}
+	if h.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(line + "\n")
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Search returns the newest entry at or before index from that contains
+// query, or -1.
+func (h *History) Search(query string, from int) int {
+	for i := min(from, len(h.entries)-1); i >= 0; i-- {
+		if strings.Contains(h.entries[i], query) {
+			return i
+		}
+	}
+	return -1
+}
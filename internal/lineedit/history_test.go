@@ -0,0 +1,61 @@
+package lineedit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHistoryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex", "history")
+	h, err := LoadHistory(path, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, line := range []string{"map", "map", " ", "bag", "explore", "catch"} {
+		if err := h.Add(line); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if h.Len() != 3 || h.At(0) != "bag" {
+		t.Errorf("Error - History Doesn't Match: Actual - %v vs Expected - [bag explore catch]", h.entries)
+	}
+
+	h, err = LoadHistory(path, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(h.entries, " ") != "bag explore catch" {
+		t.Errorf("Error - Loaded History Doesn't Match: Actual - %v vs Expected - [bag explore catch]", h.entries)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != "bag\nexplore\ncatch\n" {
+		t.Errorf("Error - History file wasn't trimmed: %q", data)
+	}
+}
+
+func TestHistorySearch(t *testing.T) {
+	h := NewHistory(0)
+	for _, line := range []string{"catch pikachu", "bag", "inspect pikachu"} {
+		h.Add(line)
+	}
+	cases := []struct {
+		query    string
+		from     int
+		expected int
+	}{
+		{query: "pikachu", from: 2, expected: 2},
+		{query: "pikachu", from: 1, expected: 0},
+		{query: "pikachu", from: 10, expected: 2},
+		{query: "gyarados", from: 2, expected: -1},
+	}
+	for _, c := range cases {
+		if actual := h.Search(c.query, c.from); actual != c.expected {
+			t.Errorf("Error - Search %q from %d: Actual - %d vs Expected - %d", c.query, c.from, actual, c.expected)
+		}
+	}
+}
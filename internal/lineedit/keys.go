@@ -0,0 +1,95 @@
+package lineedit
+
+// key is a rune typed at the terminal, or one of the negative values below
+// for keys that arrive as escape sequences.
+type key rune
+
+const (
+	keyNone key = -1 - iota
+	keyUp
+	keyDown
+	keyRight
+	keyLeft
+	keyHome
+	keyEnd
+	keyDelete
+)
+
+const (
+	keyCtrlA     key = 1
+	keyCtrlB     key = 2
+	keyCtrlC     key = 3
+	keyCtrlD     key = 4
+	keyCtrlE     key = 5
+	keyCtrlF     key = 6
+	keyCtrlG     key = 7
+	keyCtrlH     key = 8
+	keyTab       key = 9
+	keyNewline   key = 10
+	keyCtrlK     key = 11
+	keyEnter     key = 13
+	keyCtrlN     key = 14
+	keyCtrlP     key = 16
+	keyCtrlR     key = 18
+	keyCtrlU     key = 21
+	keyCtrlW     key = 23
+	keyEscape    key = 27
+	keyBackspace key = 127
+)
+
+// readKey reads one key, decoding the ANSI escape sequences terminals send
+// for the arrow, home, end and delete keys. Sequences it doesn't know are
+// read in full and returned as keyNone.
+func (e *Editor) readKey() (key, error) {
+	r, _, err := e.in.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+	if key(r) != keyEscape {
+		return key(r), nil
+	}
+	r, _, err = e.in.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+	if r != '[' && r != 'O' {
+		return keyNone, nil
+	}
+	params := []rune{}
+	for {
+		r, _, err = e.in.ReadRune()
+		if err != nil {
+			return 0, err
+		}
+		// Parameters and intermediates are below '@'; the final byte
+		// ends the sequence.
+		if r >= '@' && r <= '~' {
+			break
+		}
+		params = append(params, r)
+	}
+	switch r {
+	case 'A':
+		return keyUp, nil
+	case 'B':
+		return keyDown, nil
+	case 'C':
+		return keyRight, nil
+	case 'D':
+		return keyLeft, nil
+	case 'H':
+		return keyHome, nil
+	case 'F':
+		return keyEnd, nil
+	case '~':
+		switch string(params) {
+		case "1", "7":
+			return keyHome, nil
+		case "4", "8":
+			return keyEnd, nil
+		case "3":
+			return keyDelete, nil
+		}
+	}
+	return keyNone, nil
+}
@@ -0,0 +1,308 @@
+// Package lineedit reads lines from a terminal with emacs-style editing,
+// history that persists across sessions, Ctrl-R history search and tab
+// completion. The editor only needs an io.Reader and an io.Writer; when it
+// is reading from a terminal it switches the terminal to raw mode for the
+// duration of each ReadLine.
+package lineedit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInterrupted is returned by ReadLine when Ctrl-C is pressed.
+var ErrInterrupted = errors.New("lineedit: interrupted")
+
+// Completer returns the candidates for the word ending at the end of line,
+// which is the text before the cursor. The editor keeps only the candidates
+// that start with what has been typed of the word, so a completer may
+// return every value that fits the position.
+type Completer func(line string) []string
+
+type Editor struct {
+	Prompt   string
+	Complete Completer
+	History  *History
+
+	in  *bufio.Reader
+	out io.Writer
+	// fd is the terminal put into raw mode while reading, or -1.
+	fd int
+
+	buf []rune
+	pos int
+	// histIndex is the history entry being shown; History.Len() is the
+	// line being typed, which is kept in pending while browsing.
+	histIndex int
+	pending   []rune
+}
+
+// New returns an editor that reads keys from in and draws the line on out.
+// It keeps its history in memory until History is replaced.
+func New(in io.Reader, out io.Writer) *Editor {
+	return &Editor{
+		History: NewHistory(DefaultHistorySize),
+		in:      bufio.NewReader(in),
+		out:     out,
+		fd:      -1,
+	}
+}
+
+// NewTerminal returns an editor for the terminal f, or an error if f is not
+// a terminal this platform can put into raw mode.
+func NewTerminal(f *os.File, out io.Writer) (*Editor, error) {
+	fd := int(f.Fd())
+	if !isTerminal(fd) {
+		return nil, errors.New("lineedit: not a terminal")
+	}
+	e := New(f, out)
+	e.fd = fd
+	return e, nil
+}
+
+// ReadLine shows the prompt and returns the line once Enter is pressed,
+// adding it to the history. It returns io.EOF on Ctrl-D at an empty line or
+// at the end of the input, and ErrInterrupted on Ctrl-C.
+func (e *Editor) ReadLine() (string, error) {
+	if e.fd >= 0 {
+		state, err := makeRaw(e.fd)
+		if err != nil {
+			return "", err
+		}
+		defer restore(e.fd, state)
+	}
+	e.buf, e.pos = nil, 0
+	e.histIndex, e.pending = e.History.Len(), nil
+	e.refresh()
+	for {
+		k, err := e.readKey()
+		if err != nil {
+			if errors.Is(err, io.EOF) && len(e.buf) > 0 {
+				return e.accept()
+			}
+			return "", err
+		}
+		if k == keyCtrlR {
+			k, err = e.search()
+			if err != nil {
+				return "", err
+			}
+		}
+		switch k {
+		case keyEnter, keyNewline:
+			return e.accept()
+		case keyCtrlC:
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", ErrInterrupted
+		case keyCtrlD:
+			if len(e.buf) == 0 {
+				return "", io.EOF
+			}
+			e.deleteRunes(e.pos, e.pos+1)
+		case keyTab:
+			e.complete()
+		case keyBackspace, keyCtrlH:
+			e.deleteRunes(e.pos-1, e.pos)
+		case keyDelete:
+			e.deleteRunes(e.pos, e.pos+1)
+		case keyLeft, keyCtrlB:
+			e.pos = max(e.pos-1, 0)
+		case keyRight, keyCtrlF:
+			e.pos = min(e.pos+1, len(e.buf))
+		case keyHome, keyCtrlA:
+			e.pos = 0
+		case keyEnd, keyCtrlE:
+			e.pos = len(e.buf)
+		case keyUp, keyCtrlP:
+			e.browse(e.histIndex - 1)
+		case keyDown, keyCtrlN:
+			e.browse(e.histIndex + 1)
+		case keyCtrlK:
+			e.buf = e.buf[:e.pos]
+		case keyCtrlU:
+			e.deleteRunes(0, e.pos)
+		case keyCtrlW:
+			start := e.pos
+			for start > 0 && unicode.IsSpace(e.buf[start-1]) {
+				start--
+			}
+			for start > 0 && !unicode.IsSpace(e.buf[start-1]) {
+				start--
+			}
+			e.deleteRunes(start, e.pos)
+		default:
+			if k >= 0 && unicode.IsPrint(rune(k)) {
+				e.insert([]rune{rune(k)})
+			}
+		}
+		e.refresh()
+	}
+}
+
+func (e *Editor) accept() (string, error) {
+	fmt.Fprint(e.out, "\r\n")
+	line := string(e.buf)
+	// History is best effort; failing to save it shouldn't lose the line.
+	e.History.Add(line)
+	return line, nil
+}
+
+// refresh redraws the prompt and line and puts the cursor back in place.
+func (e *Editor) refresh() {
+	fmt.Fprintf(e.out, "\r%s%s\x1b[K", e.Prompt, string(e.buf))
+	if back := len(e.buf) - e.pos; back > 0 {
+		fmt.Fprintf(e.out, "\x1b[%dD", back)
+	}
+}
+
+func (e *Editor) insert(r []rune) {
+	buf := make([]rune, 0, len(e.buf)+len(r))
+	buf = append(buf, e.buf[:e.pos]...)
+	buf = append(buf, r...)
+	e.buf = append(buf, e.buf[e.pos:]...)
+	e.pos += len(r)
+}
+
+// deleteRunes removes buf[from:to], clamped to the line.
+func (e *Editor) deleteRunes(from, to int) {
+	from, to = max(from, 0), min(to, len(e.buf))
+	if from >= to {
+		return
+	}
+	e.buf = append(e.buf[:from], e.buf[to:]...)
+	if e.pos > to {
+		e.pos -= to - from
+	} else if e.pos > from {
+		e.pos = from
+	}
+}
+
+// browse shows history entry i, where History.Len() is the line that was
+// being typed before browsing started.
+func (e *Editor) browse(i int) {
+	if i < 0 || i > e.History.Len() || i == e.histIndex {
+		return
+	}
+	if e.histIndex == e.History.Len() {
+		e.pending = append([]rune(nil), e.buf...)
+	}
+	e.histIndex = i
+	if i == e.History.Len() {
+		e.buf = append([]rune(nil), e.pending...)
+	} else {
+		e.buf = []rune(e.History.At(i))
+	}
+	e.pos = len(e.buf)
+}
+
+// complete extends the word before the cursor. A single candidate is
+// completed with a trailing space; several are completed as far as they
+// agree and listed if that doesn't add anything.
+func (e *Editor) complete() {
+	if e.Complete == nil {
+		return
+	}
+	head := string(e.buf[:e.pos])
+	word := head[strings.LastIndexFunc(head, unicode.IsSpace)+1:]
+	matches := []string{}
+	seen := make(map[string]bool)
+	for _, candidate := range e.Complete(head) {
+		if strings.HasPrefix(candidate, word) && !seen[candidate] {
+			seen[candidate] = true
+			matches = append(matches, candidate)
+		}
+	}
+	sort.Strings(matches)
+	switch len(matches) {
+	case 0:
+		fmt.Fprint(e.out, "\a")
+	case 1:
+		e.insert([]rune(strings.TrimPrefix(matches[0], word) + " "))
+	default:
+		prefix := commonPrefix(matches)
+		if len(prefix) > len(word) {
+			e.insert([]rune(strings.TrimPrefix(prefix, word)))
+			return
+		}
+		fmt.Fprintf(e.out, "\r\n%s\r\n", strings.Join(matches, "  "))
+	}
+}
+
+func commonPrefix(words []string) string {
+	prefix := words[0]
+	for _, word := range words[1:] {
+		for !strings.HasPrefix(word, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	// Don't split a rune the candidates only share the first bytes of.
+	for !utf8.ValidString(prefix) {
+		prefix = prefix[:len(prefix)-1]
+	}
+	return prefix
+}
+
+// search runs an incremental reverse search of the history. Typing narrows
+// the search, Ctrl-R finds the next older match and Ctrl-G or Ctrl-C cancel
+// it. Any other key puts the match on the line and is returned so ReadLine
+// can handle it, which makes Enter run the match straight away.
+func (e *Editor) search() (key, error) {
+	original := append([]rune(nil), e.buf...)
+	query := []rune{}
+	index := e.History.Len()
+	failed := false
+	for {
+		match := ""
+		if index < e.History.Len() {
+			match = e.History.At(index)
+		}
+		status := "reverse-i-search"
+		if failed {
+			status = "failing reverse-i-search"
+		}
+		fmt.Fprintf(e.out, "\r(%s)`%s': %s\x1b[K", status, string(query), match)
+		k, err := e.readKey()
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case k == keyCtrlR:
+			e.searchFrom(string(query), index-1, &index, &failed)
+		case k == keyBackspace || k == keyCtrlH:
+			if len(query) > 0 {
+				query = query[:len(query)-1]
+				e.searchFrom(string(query), e.History.Len()-1, &index, &failed)
+			}
+		case k == keyCtrlG || k == keyCtrlC:
+			e.buf, e.pos = original, len(original)
+			return keyNone, nil
+		case k >= 0 && unicode.IsPrint(rune(k)):
+			query = append(query, rune(k))
+			e.searchFrom(string(query), min(index, e.History.Len()-1), &index, &failed)
+		default:
+			if match != "" {
+				e.buf = []rune(match)
+				e.pos = len(e.buf)
+				e.histIndex = index
+			}
+			return k, nil
+		}
+	}
+}
+
+// searchFrom moves index to the newest entry at or before from that
+// contains query, or marks the search failed and leaves index alone.
+func (e *Editor) searchFrom(query string, from int, index *int, failed *bool) {
+	if i := e.History.Search(query, from); i >= 0 {
+		*index, *failed = i, false
+	} else {
+		*failed = true
+	}
+}
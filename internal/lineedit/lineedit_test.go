@@ -0,0 +1,137 @@
+package lineedit
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// readLines feeds input to a fresh editor and returns every line it reads
+// before the input runs out.
+func readLines(t *testing.T, e *Editor) []string {
+	t.Helper()
+	lines := []string{}
+	for {
+		line, err := e.ReadLine()
+		if errors.Is(err, io.EOF) {
+			return lines
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		lines = append(lines, line)
+	}
+}
+
+func TestReadLineEditing(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "catch\r", expected: "catch"},
+		{input: "cathc\x7f\x7fch\r", expected: "catch"},
+		{input: "atch\x01c\r", expected: "catch"},
+		{input: "cch\x1b[D\x1b[Dat\r", expected: "catch"},
+		{input: "catch pikachu\x17\r", expected: "catch "},
+		{input: "xx\x1b[Hcatch\x0b\r", expected: "catch"},
+		{input: "oops\x15catch\r", expected: "catch"},
+		{input: "ccatch\x01\x1b[3~\r", expected: "catch"},
+		{input: "catch", expected: "catch"},
+		{input: "poké\r", expected: "poké"},
+	}
+	for _, c := range cases {
+		e := New(strings.NewReader(c.input), io.Discard)
+		lines := readLines(t, e)
+		if len(lines) != 1 || lines[0] != c.expected {
+			t.Errorf("Error - Lines Don't Match for %q: Actual - %q vs Expected - %q", c.input, lines, c.expected)
+		}
+	}
+}
+
+func TestReadLineInterrupt(t *testing.T) {
+	e := New(strings.NewReader("catch\x03"), io.Discard)
+	if _, err := e.ReadLine(); !errors.Is(err, ErrInterrupted) {
+		t.Errorf("Error - Actual - %v vs Expected - %v", err, ErrInterrupted)
+	}
+	e = New(strings.NewReader("\x04"), io.Discard)
+	if _, err := e.ReadLine(); !errors.Is(err, io.EOF) {
+		t.Errorf("Error - Actual - %v vs Expected - %v", err, io.EOF)
+	}
+}
+
+func TestHistoryNavigation(t *testing.T) {
+	// Up twice reaches "map", down once comes back to "bag", and down again
+	// restores the half-typed line.
+	e := New(strings.NewReader("map\rbag\r\x1b[A\x1b[A\rpar\x1b[A\x1b[B\x1b[B\x1b[Bty\r\x10\x10\x10\x0e\r"), io.Discard)
+	lines := readLines(t, e)
+	expected := []string{"map", "bag", "map", "party", "map"}
+	if strings.Join(lines, "|") != strings.Join(expected, "|") {
+		t.Errorf("Error - Lines Don't Match: Actual - %q vs Expected - %q", lines, expected)
+	}
+}
+
+func TestReverseSearch(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		// The newest match runs when Enter is pressed.
+		{input: "\x12pik\r", expected: "inspect pikachu"},
+		// Ctrl-R again finds an older match.
+		{input: "\x12pik\x12\r", expected: "catch pikachu"},
+		// Backspace widens the search again.
+		{input: "\x12map\x7f\x7f\x7fbag\r", expected: "bag"},
+		// Any other key edits the match.
+		{input: "\x12cat\x1b[D\x05 --ball great-ball\r", expected: "catch pikachu --ball great-ball"},
+		// Ctrl-G cancels, leaving what was typed.
+		{input: "ex\x12pik\x07plore\r", expected: "explore"},
+	}
+	for _, c := range cases {
+		e := New(strings.NewReader(c.input), io.Discard)
+		for _, line := range []string{"catch pikachu", "bag", "inspect pikachu"} {
+			e.History.Add(line)
+		}
+		lines := readLines(t, e)
+		if len(lines) != 1 || lines[0] != c.expected {
+			t.Errorf("Error - Lines Don't Match for %q: Actual - %q vs Expected - %q", c.input, lines, c.expected)
+		}
+	}
+}
+
+func TestComplete(t *testing.T) {
+	complete := func(line string) []string {
+		if !strings.Contains(line, " ") {
+			return []string{"catch", "evolution", "evolve", "exit", "explore"}
+		}
+		return []string{"pikachu", "pidgey"}
+	}
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "ca\t\r", expected: "catch "},
+		{input: "evo\t\r", expected: "evol"},
+		{input: "e\tvolve\r", expected: "evolve"},
+		{input: "inspect pik\t\r", expected: "inspect pikachu "},
+		{input: "inspect pi\t\t\r", expected: "inspect pi"},
+		{input: "xyz\t\r", expected: "xyz"},
+	}
+	for _, c := range cases {
+		var out strings.Builder
+		e := New(strings.NewReader(c.input), &out)
+		e.Complete = complete
+		lines := readLines(t, e)
+		if len(lines) != 1 || lines[0] != c.expected {
+			t.Errorf("Error - Lines Don't Match for %q: Actual - %q vs Expected - %q", c.input, lines, c.expected)
+		}
+	}
+
+	var out strings.Builder
+	e := New(strings.NewReader("inspect pi\t\r"), &out)
+	e.Complete = complete
+	readLines(t, e)
+	if !strings.Contains(out.String(), "pidgey  pikachu") {
+		t.Errorf("Error - Candidates not listed: %q", out.String())
+	}
+}
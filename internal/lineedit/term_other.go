@@ -0,0 +1,21 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package lineedit
+
+import "errors"
+
+// Raw mode isn't supported here, so NewTerminal always fails and callers
+// fall back to reading plain lines.
+type termState struct{}
+
+func isTerminal(fd int) bool {
+	return false
+}
+
+func makeRaw(fd int) (*termState, error) {
+	return nil, errors.ErrUnsupported
+}
+
+func restore(fd int, state *termState) error {
+	return nil
+}
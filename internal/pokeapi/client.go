@@ -0,0 +1,136 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/smwalke83/pokedex/internal/pokecache"
+)
+
+const DefaultBaseURL = "https://pokeapi.co/api/v2"
+
+type Client struct {
+	// Retry is how failed requests are retried.
+	Retry RetryPolicy
+	// Logger gets a debug record for every request attempt.
+	Logger *slog.Logger
+
+	baseURL    string
+	httpClient *http.Client
+	cache      *pokecache.Cache
+}
+
+// NewClient returns a Client that talks to the API at baseURL. An empty
+// baseURL means DefaultBaseURL, a nil httpClient means http.DefaultClient and
+// a nil cache disables caching. It retries with DefaultRetryPolicy and
+// doesn't log.
+func NewClient(baseURL string, httpClient *http.Client, cache *pokecache.Cache) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		Retry:      DefaultRetryPolicy,
+		Logger:     slog.New(slog.DiscardHandler),
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: httpClient,
+		cache:      cache,
+	}
+}
+
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// get fetches url, from the cache if possible, and decodes it into v. Errors
+// are an *HTTPError, *NetworkError or *DecodeError.
+func (c *Client) get(ctx context.Context, url string, v any) error {
+	body, ok := c.cacheGet(url)
+	if !ok {
+		var err error
+		body, err = c.fetch(ctx, url)
+		if err != nil {
+			return err
+		}
+		c.cacheAdd(url, body)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return &DecodeError{URL: url, Err: err}
+	}
+	return nil
+}
+
+// fetch GETs url, retrying failures as c.Retry allows.
+func (c *Client) fetch(ctx context.Context, url string) ([]byte, error) {
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		body, err := c.fetchOnce(ctx, url, attempt)
+		if err == nil {
+			return body, nil
+		}
+		delay, ok := c.Retry.backoff(attempt, err)
+		if !ok || attempt >= c.Retry.MaxAttempts {
+			return nil, err
+		}
+		if c.Retry.Deadline > 0 && time.Since(start)+delay > c.Retry.Deadline {
+			c.Logger.Debug("giving up before the retry deadline", "url", url, "attempt", attempt, "delay", delay)
+			return nil, err
+		}
+		c.Logger.Debug("retrying request", "url", url, "attempt", attempt, "delay", delay, "error", err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, &NetworkError{URL: url, Err: ctx.Err()}
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) fetchOnce(ctx context.Context, url string, attempt int) ([]byte, error) {
+	start := time.Now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		c.Logger.Debug("request failed", "url", url, "attempt", attempt, "elapsed", time.Since(start), "error", err)
+		return nil, &NetworkError{URL: url, Err: err}
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	c.Logger.Debug("request", "url", url, "attempt", attempt, "status", res.StatusCode, "bytes", len(body), "elapsed", time.Since(start))
+	if err != nil {
+		return nil, &NetworkError{URL: url, Err: err}
+	}
+	if res.StatusCode > 299 {
+		return nil, &HTTPError{
+			URL:        url,
+			StatusCode: res.StatusCode,
+			RetryAfter: parseRetryAfter(res.Header.Get("Retry-After"), time.Now()),
+		}
+	}
+	return body, nil
+}
+
+func (c *Client) cacheGet(key string) ([]byte, bool) {
+	if c.cache == nil {
+		return nil, false
+	}
+	return c.cache.Get(key)
+}
+
+func (c *Client) cacheAdd(key string, val []byte) {
+	if c.cache == nil {
+		return
+	}
+	c.cache.Add(key, val)
+}
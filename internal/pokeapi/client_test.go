@@ -0,0 +1,181 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"fmt"
+	"github.com/smwalke83/pokedex/internal/pokecache"
+	"strings"
+)
+
+func newTestServer(t *testing.T, routes map[string]string) (*httptest.Server, *int) {
+	t.Helper()
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func TestListLocationAreas(t *testing.T) {
+	srv, _ := newTestServer(t, map[string]string{
+		"/location-area": `{"count":2,"next":"http://example.com/next","previous":null,"results":[{"name":"canalave-city-area","url":""},{"name":"eterna-city-area","url":""}]}`,
+	})
+	client := NewClient(srv.URL, nil, nil)
+	list, err := client.ListLocationAreas(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list.Results) != 2 || list.Results[0].Name != "canalave-city-area" {
+		t.Errorf("unexpected results: %+v", list.Results)
+	}
+	if list.Next == nil || *list.Next != "http://example.com/next" {
+		t.Errorf("expected next page url, got %v", list.Next)
+	}
+	if list.Previous != nil {
+		t.Errorf("expected no previous page, got %v", *list.Previous)
+	}
+}
+
+func TestCachedLocationAreas(t *testing.T) {
+	srv, _ := newTestServer(t, map[string]string{
+		"/location-area":                       `{"count":2,"next":null,"previous":null,"results":[{"name":"canalave-city-area","url":""},{"name":"eterna-city-area","url":""}]}`,
+		"/location-area/viridian-forest-area/": `{"id":321,"name":"viridian-forest-area"}`,
+		"/location-area/":                      `{"count":1,"next":null,"previous":null,"results":[{"name":"sinnoh-pokemon-league-area","url":""}]}`,
+		"/pokemon/pikachu/":                    `{"id":25,"name":"pikachu"}`,
+	})
+	client := NewClient(srv.URL, nil, pokecache.NewCache(time.Minute))
+	if names := client.CachedLocationAreas(); len(names) != 0 {
+		t.Errorf("expected no cached areas, got %v", names)
+	}
+	ctx := context.Background()
+	client.ListLocationAreas(ctx, nil)
+	client.GetLocationArea(ctx, "viridian-forest-area")
+	client.GetPokemon(ctx, "pikachu")
+	client.ListNames(ctx, "location-area")
+	names := client.CachedLocationAreas()
+	if strings.Join(names, " ") != "canalave-city-area eterna-city-area sinnoh-pokemon-league-area viridian-forest-area" {
+		t.Errorf("unexpected cached areas: %v", names)
+	}
+}
+
+func TestListNamesFollowsPages(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("offset") == "" {
+			fmt.Fprintf(w, `{"count":3,"next":"%s/pokemon/?offset=2","results":[{"name":"bulbasaur"},{"name":"ivysaur"}]}`, srv.URL)
+			return
+		}
+		w.Write([]byte(`{"count":3,"next":null,"results":[{"name":"venusaur"}]}`))
+	}))
+	t.Cleanup(srv.Close)
+	client := NewClient(srv.URL, nil, pokecache.NewCache(time.Minute))
+	names, err := client.ListNames(context.Background(), "pokemon")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(names, " ") != "bulbasaur ivysaur venusaur" {
+		t.Errorf("unexpected names: %v", names)
+	}
+}
+
+func TestGetPokemonUsesCache(t *testing.T) {
+	srv, hits := newTestServer(t, map[string]string{
+		"/pokemon/pikachu/": `{"id":25,"name":"pikachu","base_experience":112}`,
+	})
+	client := NewClient(srv.URL, nil, pokecache.NewCache(time.Minute))
+	for i := 0; i < 2; i++ {
+		poke, err := client.GetPokemon(context.Background(), "pikachu")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if poke.ID != 25 || poke.BaseExperience != 112 {
+			t.Errorf("unexpected pokemon: %+v", poke)
+		}
+	}
+	if *hits != 1 {
+		t.Errorf("expected 1 request, got %d", *hits)
+	}
+}
+
+func TestGetLocationAreaNotFound(t *testing.T) {
+	srv, _ := newTestServer(t, map[string]string{})
+	client := NewClient(srv.URL, nil, nil)
+	_, err := client.GetLocationArea(context.Background(), "nowhere")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected not found for a missing area, got %v", err)
+	}
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.URL != srv.URL+"/location-area/nowhere/" {
+		t.Errorf("expected the error to carry the url, got %v", err)
+	}
+}
+
+func TestErrorKinds(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pokemon/busy/":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/pokemon/broken/":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/pokemon/garbled/":
+			w.Write([]byte("<html>"))
+		}
+	}))
+	client := NewClient(srv.URL, nil, nil)
+	client.Retry = RetryPolicy{MaxAttempts: 1}
+	ctx := context.Background()
+
+	_, err := client.GetPokemon(ctx, "busy")
+	if !errors.Is(err, ErrRateLimited) || errors.Is(err, ErrNotFound) {
+		t.Errorf("expected rate limited, got %v", err)
+	}
+	_, err = client.GetPokemon(ctx, "broken")
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != 500 || errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a 500 status, got %v", err)
+	}
+	_, err = client.GetPokemon(ctx, "garbled")
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Errorf("expected a decode error, got %v", err)
+	}
+
+	srv.Close()
+	_, err = client.GetPokemon(ctx, "pikachu")
+	var networkErr *NetworkError
+	if !errors.As(err, &networkErr) {
+		t.Errorf("expected a network error, got %v", err)
+	}
+}
+
+func TestGetPokemonCancelled(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(block)
+	client := NewClient(srv.URL, nil, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.GetPokemon(ctx, "pikachu")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package pokeapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var (
+	// ErrNotFound matches an *HTTPError for a resource the API doesn't
+	// have, usually a misspelt name.
+	ErrNotFound = errors.New("not found")
+	// ErrRateLimited matches an *HTTPError for a request the API refused
+	// because too many were made.
+	ErrRateLimited = errors.New("rate limited")
+)
+
+// HTTPError is returned when the API answers with an error status.
+type HTTPError struct {
+	URL        string
+	StatusCode int
+	// RetryAfter is how long the server asked to be left alone for, or 0.
+	RetryAfter time.Duration
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("GET %s: %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func (e *HTTPError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// NetworkError is returned when a request can't be sent or its response
+// can't be read. It wraps the underlying error, so a cancelled request
+// still matches context.Canceled.
+type NetworkError struct {
+	URL string
+	Err error
+}
+
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("GET %s: %v", e.URL, e.Err)
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError is returned when a response isn't the JSON that was expected.
+type DecodeError struct {
+	URL string
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decoding %s: %v", e.URL, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
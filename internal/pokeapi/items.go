@@ -0,0 +1,16 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+func (c *Client) GetItem(ctx context.Context, name string) (Item, error) {
+	var item Item
+	if name == "" {
+		return item, errors.New("item name is empty")
+	}
+	err := c.get(ctx, c.baseURL+"/item/"+url.PathEscape(name)+"/", &item)
+	return item, err
+}
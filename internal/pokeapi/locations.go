@@ -0,0 +1,66 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// ListLocationAreas fetches one page of location areas. A nil pageURL
+// fetches the first page.
+func (c *Client) ListLocationAreas(ctx context.Context, pageURL *string) (LocationAreaList, error) {
+	u := c.baseURL + "/location-area"
+	if pageURL != nil {
+		u = *pageURL
+	}
+	var list LocationAreaList
+	err := c.get(ctx, u, &list)
+	return list, err
+}
+
+func (c *Client) GetLocationArea(ctx context.Context, name string) (LocationArea, error) {
+	var loc LocationArea
+	if name == "" {
+		return loc, errors.New("location area name is empty")
+	}
+	err := c.get(ctx, c.baseURL+"/location-area/"+url.PathEscape(name)+"/", &loc)
+	return loc, err
+}
+
+// CachedLocationAreas returns the names of the location areas the client
+// has cached, either fetched by name or listed on a cached page, without
+// making any requests.
+func (c *Client) CachedLocationAreas() []string {
+	if c.cache == nil {
+		return nil
+	}
+	prefix := c.baseURL + "/location-area"
+	names := []string{}
+	for _, key := range c.cache.Keys() {
+		rest, ok := strings.CutPrefix(key, prefix)
+		if !ok {
+			continue
+		}
+		name, ok := strings.CutPrefix(rest, "/")
+		if ok && !strings.HasPrefix(name, "?") && strings.Trim(name, "/") != "" {
+			if name, err := url.PathUnescape(strings.Trim(name, "/")); err == nil {
+				names = append(names, name)
+			}
+			continue
+		}
+		body, ok := c.cache.Get(key)
+		if !ok {
+			continue
+		}
+		var list LocationAreaList
+		if json.Unmarshal(body, &list) != nil {
+			continue
+		}
+		for _, result := range list.Results {
+			names = append(names, result.Name)
+		}
+	}
+	return names
+}
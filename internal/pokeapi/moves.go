@@ -0,0 +1,16 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+func (c *Client) GetMove(ctx context.Context, name string) (Move, error) {
+	var move Move
+	if name == "" {
+		return move, errors.New("move name is empty")
+	}
+	err := c.get(ctx, c.baseURL+"/move/"+url.PathEscape(name)+"/", &move)
+	return move, err
+}
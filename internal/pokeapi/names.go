@@ -0,0 +1,33 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// namesPageSize is how many names ListNames asks for at a time; large
+// enough that most endpoints fit on one or two pages.
+const namesPageSize = 1000
+
+// ListNames fetches the name of every resource on a list endpoint such as
+// "pokemon" or "location-area", following the pages. Every page is cached
+// like any other response.
+func (c *Client) ListNames(ctx context.Context, resource string) ([]string, error) {
+	u := fmt.Sprintf("%s/%s/?limit=%d", c.baseURL, resource, namesPageSize)
+	names := []string{}
+	for u != "" {
+		var list NamedAPIResourceList
+		if err := c.get(ctx, u, &list); err != nil {
+			return nil, err
+		}
+		for _, result := range list.Results {
+			names = append(names, result.Name)
+		}
+		next := ""
+		if list.Next != nil && *list.Next != u {
+			next = *list.Next
+		}
+		u = next
+	}
+	return names, nil
+}
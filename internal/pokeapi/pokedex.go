@@ -0,0 +1,33 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+func (c *Client) GetPokedex(ctx context.Context, name string) (Pokedex, error) {
+	var dex Pokedex
+	if name == "" {
+		return dex, errors.New("pokedex name is empty")
+	}
+	err := c.get(ctx, c.baseURL+"/pokedex/"+url.PathEscape(name)+"/", &dex)
+	return dex, err
+}
+
+// ListGenerations fetches every generation. There are few enough that they
+// fit on one page.
+func (c *Client) ListGenerations(ctx context.Context) (NamedAPIResourceList, error) {
+	var list NamedAPIResourceList
+	err := c.get(ctx, c.baseURL+"/generation/?limit=100", &list)
+	return list, err
+}
+
+func (c *Client) GetGeneration(ctx context.Context, name string) (Generation, error) {
+	var gen Generation
+	if name == "" {
+		return gen, errors.New("generation name is empty")
+	}
+	err := c.get(ctx, c.baseURL+"/generation/"+url.PathEscape(name)+"/", &gen)
+	return gen, err
+}
@@ -0,0 +1,46 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+func (c *Client) GetPokemon(ctx context.Context, name string) (Pokemon, error) {
+	var poke Pokemon
+	if name == "" {
+		return poke, errors.New("pokemon name is empty")
+	}
+	err := c.get(ctx, c.baseURL+"/pokemon/"+url.PathEscape(name)+"/", &poke)
+	return poke, err
+}
+
+func (c *Client) GetPokemonSpecies(ctx context.Context, name string) (PokemonSpecies, error) {
+	var species PokemonSpecies
+	if name == "" {
+		return species, errors.New("species name is empty")
+	}
+	err := c.get(ctx, c.baseURL+"/pokemon-species/"+url.PathEscape(name)+"/", &species)
+	return species, err
+}
+
+// GetPokemonEncounters fetches the areas listed at a pokemon's
+// LocationAreaEncounters URL.
+func (c *Client) GetPokemonEncounters(ctx context.Context, encountersURL string) ([]LocationAreaEncounter, error) {
+	var encounters []LocationAreaEncounter
+	if encountersURL == "" {
+		return encounters, errors.New("encounters url is empty")
+	}
+	err := c.get(ctx, encountersURL, &encounters)
+	return encounters, err
+}
+
+// GetEvolutionChain fetches the chain at a species' EvolutionChain URL.
+func (c *Client) GetEvolutionChain(ctx context.Context, chainURL string) (EvolutionChain, error) {
+	var chain EvolutionChain
+	if chainURL == "" {
+		return chain, errors.New("evolution chain url is empty")
+	}
+	err := c.get(ctx, chainURL, &chain)
+	return chain, err
+}
@@ -0,0 +1,86 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried. Only failures that
+// may go away are retried: network errors, rate limiting and 5xx statuses.
+type RetryPolicy struct {
+	// MaxAttempts is how many times a request is tried in all; 1 or less
+	// disables retries.
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry, doubled for each one
+	// after it up to MaxDelay. Each wait is jittered so that clients don't
+	// retry in lockstep.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Deadline bounds the time spent on a request across all its attempts;
+	// a retry that would start after it isn't made. 0 leaves it to the
+	// request's context.
+	Deadline time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   250 * time.Millisecond,
+	MaxDelay:    8 * time.Second,
+	Deadline:    20 * time.Second,
+}
+
+// backoff returns how long to wait after the given attempt failed with err,
+// and false if err isn't worth retrying. A Retry-After from the server is
+// used as is.
+func (p RetryPolicy) backoff(attempt int, err error) (time.Duration, bool) {
+	if !retryable(err) {
+		return 0, false
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.RetryAfter > 0 {
+		return httpErr.RetryAfter, true
+	}
+	delay := p.MaxDelay
+	if shift := attempt - 1; shift < 31 && p.BaseDelay<<shift < p.MaxDelay {
+		delay = p.BaseDelay << shift
+	}
+	if delay <= 0 {
+		return 0, true
+	}
+	// Wait at least half the delay, and a random part of the rest.
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1)), true
+}
+
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var networkErr *NetworkError
+	if errors.As(err, &networkErr) {
+		return true
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+	}
+	return false
+}
+
+// parseRetryAfter reads a Retry-After header, which is either a number of
+// seconds or an HTTP date. It returns 0 if the header is missing or invalid.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
@@ -0,0 +1,167 @@
+package pokeapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newFlakyServer answers each request with the next of statuses, then with
+// a pokemon once they run out.
+func newFlakyServer(t *testing.T, header http.Header, statuses ...int) (*httptest.Server, *int) {
+	t.Helper()
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if hits <= len(statuses) {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(statuses[hits-1])
+			return
+		}
+		w.Write([]byte(`{"id":25,"name":"pikachu"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+var fastRetries = RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond, MaxDelay: 4 * time.Millisecond}
+
+func TestRetryRecovers(t *testing.T) {
+	srv, hits := newFlakyServer(t, nil, 503, 502)
+	client := NewClient(srv.URL, nil, nil)
+	client.Retry = fastRetries
+	var logs bytes.Buffer
+	client.Logger = slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	poke, err := client.GetPokemon(context.Background(), "pikachu")
+	if err != nil || poke.Name != "pikachu" {
+		t.Fatalf("expected pikachu after retrying, got %v, %v", poke.Name, err)
+	}
+	if *hits != 3 {
+		t.Errorf("expected 3 requests, got %d", *hits)
+	}
+	for _, want := range []string{"attempt=1 status=503", "attempt=2 status=502", "attempt=3 status=200", "retrying request"} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("expected the log to contain %q, got:\n%s", want, logs.String())
+		}
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	srv, hits := newFlakyServer(t, nil, 500, 500, 500, 500, 500)
+	client := NewClient(srv.URL, nil, nil)
+	client.Retry = fastRetries
+	client.Retry.MaxAttempts = 3
+	_, err := client.GetPokemon(context.Background(), "pikachu")
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != 500 {
+		t.Errorf("expected the last 500, got %v", err)
+	}
+	if *hits != 3 {
+		t.Errorf("expected 3 requests, got %d", *hits)
+	}
+}
+
+func TestRetrySkipsPermanentErrors(t *testing.T) {
+	srv, hits := newFlakyServer(t, nil, 404)
+	client := NewClient(srv.URL, nil, nil)
+	client.Retry = fastRetries
+	_, err := client.GetPokemon(context.Background(), "pikachu")
+	if !errors.Is(err, ErrNotFound) || *hits != 1 {
+		t.Errorf("expected one not found request, got %v after %d", err, *hits)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	srv, hits := newFlakyServer(t, http.Header{"Retry-After": {"0"}}, 429)
+	client := NewClient(srv.URL, nil, nil)
+	client.Retry = fastRetries
+	if _, err := client.GetPokemon(context.Background(), "pikachu"); err != nil || *hits != 2 {
+		t.Errorf("expected success on the second request, got %v after %d", err, *hits)
+	}
+
+	// Waiting as long as the server asks would pass the deadline, so the
+	// request fails straight away.
+	srv, hits = newFlakyServer(t, http.Header{"Retry-After": {"30"}}, 429)
+	client = NewClient(srv.URL, nil, nil)
+	client.Retry = fastRetries
+	client.Retry.Deadline = time.Second
+	start := time.Now()
+	_, err := client.GetPokemon(context.Background(), "pikachu")
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.RetryAfter != 30*time.Second || !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected a rate limit asking for 30s, got %v", err)
+	}
+	if *hits != 1 || time.Since(start) > 500*time.Millisecond {
+		t.Errorf("expected to give up at once, took %d requests and %v", *hits, time.Since(start))
+	}
+}
+
+func TestRetryStopsWhenCancelled(t *testing.T) {
+	srv, hits := newFlakyServer(t, nil, 503, 503, 503)
+	client := NewClient(srv.URL, nil, nil)
+	client.Retry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Minute}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.GetPokemon(ctx, "pikachu")
+	if !errors.Is(err, context.DeadlineExceeded) || *hits != 1 {
+		t.Errorf("expected the context deadline after one request, got %v after %d", err, *hits)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	err := &HTTPError{StatusCode: 503}
+	cases := []struct {
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{attempt: 1, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{attempt: 2, min: 100 * time.Millisecond, max: 200 * time.Millisecond},
+		{attempt: 3, min: 200 * time.Millisecond, max: 400 * time.Millisecond},
+		{attempt: 5, min: 500 * time.Millisecond, max: time.Second},
+		{attempt: 100, min: 500 * time.Millisecond, max: time.Second},
+	}
+	for _, c := range cases {
+		for i := 0; i < 20; i++ {
+			delay, ok := p.backoff(c.attempt, err)
+			if !ok || delay < c.min || delay > c.max {
+				t.Errorf("attempt %d: expected a delay in [%v, %v], got %v", c.attempt, c.min, c.max, delay)
+			}
+		}
+	}
+	if _, ok := p.backoff(1, &DecodeError{Err: errors.New("bad json")}); ok {
+		t.Errorf("expected decode errors not to be retried")
+	}
+	if _, ok := p.backoff(1, &NetworkError{Err: context.Canceled}); ok {
+		t.Errorf("expected cancelled requests not to be retried")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		header   string
+		expected time.Duration
+	}{
+		{header: "", expected: 0},
+		{header: "120", expected: 2 * time.Minute},
+		{header: "-5", expected: 0},
+		{header: "Mon, 01 Jan 2024 12:00:30 GMT", expected: 30 * time.Second},
+		{header: "Mon, 01 Jan 2024 11:00:00 GMT", expected: 0},
+		{header: "soon", expected: 0},
+	}
+	for _, c := range cases {
+		if actual := parseRetryAfter(c.header, now); actual != c.expected {
+			t.Errorf("Retry-After %q: expected %v, got %v", c.header, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+func (c *Client) GetType(ctx context.Context, name string) (Type, error) {
+	var t Type
+	if name == "" {
+		return t, errors.New("type name is empty")
+	}
+	err := c.get(ctx, c.baseURL+"/type/"+url.PathEscape(name)+"/", &t)
+	return t, err
+}
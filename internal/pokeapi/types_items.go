@@ -0,0 +1,49 @@
+package pokeapi
+
+type Item struct {
+	ID            int              `json:"id"`
+	Name          string           `json:"name"`
+	Cost          int              `json:"cost"`
+	Category      NamedAPIResource `json:"category"`
+	EffectEntries []struct {
+		Effect      string           `json:"effect"`
+		ShortEffect string           `json:"short_effect"`
+		Language    NamedAPIResource `json:"language"`
+	} `json:"effect_entries"`
+	FlavorTextEntries []struct {
+		Text         string           `json:"text"`
+		Language     NamedAPIResource `json:"language"`
+		VersionGroup NamedAPIResource `json:"version_group"`
+	} `json:"flavor_text_entries"`
+	Names []struct {
+		Name     string           `json:"name"`
+		Language NamedAPIResource `json:"language"`
+	} `json:"names"`
+}
+
+// DisplayName returns the English name of the item, falling back to its
+// API name.
+func (i Item) DisplayName() string {
+	for _, n := range i.Names {
+		if n.Language.Name == "en" {
+			return n.Name
+		}
+	}
+	return i.Name
+}
+
+// Description returns the English short effect of the item, falling back to
+// its most recent English flavor text.
+func (i Item) Description() string {
+	for _, e := range i.EffectEntries {
+		if e.Language.Name == "en" && e.ShortEffect != "" {
+			return e.ShortEffect
+		}
+	}
+	for j := len(i.FlavorTextEntries) - 1; j >= 0; j-- {
+		if i.FlavorTextEntries[j].Language.Name == "en" {
+			return i.FlavorTextEntries[j].Text
+		}
+	}
+	return ""
+}
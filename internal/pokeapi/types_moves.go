@@ -0,0 +1,20 @@
+package pokeapi
+
+type Move struct {
+	ID          int              `json:"id"`
+	Name        string           `json:"name"`
+	Accuracy    *int             `json:"accuracy"`
+	Power       *int             `json:"power"`
+	PP          int              `json:"pp"`
+	Priority    int              `json:"priority"`
+	Type        NamedAPIResource `json:"type"`
+	DamageClass NamedAPIResource `json:"damage_class"`
+	Meta        *MoveMeta        `json:"meta"`
+}
+
+// MoveMeta holds a move's side effects. AilmentChance is a percentage, or 0
+// when the ailment always comes with the move.
+type MoveMeta struct {
+	Ailment       NamedAPIResource `json:"ailment"`
+	AilmentChance int              `json:"ailment_chance"`
+}
@@ -0,0 +1,29 @@
+package pokeapi
+
+// NamedAPIResourceList is a page of a list endpoint.
+type NamedAPIResourceList struct {
+	Count    int                `json:"count"`
+	Next     *string            `json:"next"`
+	Previous *string            `json:"previous"`
+	Results  []NamedAPIResource `json:"results"`
+}
+
+type Pokedex struct {
+	ID             int               `json:"id"`
+	Name           string            `json:"name"`
+	IsMainSeries   bool              `json:"is_main_series"`
+	Region         *NamedAPIResource `json:"region"`
+	PokemonEntries []PokemonEntry    `json:"pokemon_entries"`
+}
+
+type PokemonEntry struct {
+	EntryNumber    int              `json:"entry_number"`
+	PokemonSpecies NamedAPIResource `json:"pokemon_species"`
+}
+
+type Generation struct {
+	ID             int                `json:"id"`
+	Name           string             `json:"name"`
+	MainRegion     NamedAPIResource   `json:"main_region"`
+	PokemonSpecies []NamedAPIResource `json:"pokemon_species"`
+}
@@ -0,0 +1,25 @@
+package pokeapi
+
+type Type struct {
+	ID                  int                 `json:"id"`
+	Name                string              `json:"name"`
+	Generation          NamedAPIResource    `json:"generation"`
+	DamageRelations     TypeRelations       `json:"damage_relations"`
+	PastDamageRelations []TypeRelationsPast `json:"past_damage_relations"`
+}
+
+type TypeRelations struct {
+	NoDamageTo       []NamedAPIResource `json:"no_damage_to"`
+	HalfDamageTo     []NamedAPIResource `json:"half_damage_to"`
+	DoubleDamageTo   []NamedAPIResource `json:"double_damage_to"`
+	NoDamageFrom     []NamedAPIResource `json:"no_damage_from"`
+	HalfDamageFrom   []NamedAPIResource `json:"half_damage_from"`
+	DoubleDamageFrom []NamedAPIResource `json:"double_damage_from"`
+}
+
+// TypeRelationsPast holds the damage relations a type had up to and
+// including Generation.
+type TypeRelationsPast struct {
+	Generation      NamedAPIResource `json:"generation"`
+	DamageRelations TypeRelations    `json:"damage_relations"`
+}
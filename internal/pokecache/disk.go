@@ -0,0 +1,189 @@
+package pokecache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const indexFile = "index.json"
+
+// DiskOptions configures the on-disk tier of a Cache. A zero TTL keeps
+// entries until they are evicted and a zero MaxBytes disables the size limit.
+type DiskOptions struct {
+	Dir      string
+	TTL      time.Duration
+	MaxBytes int64
+}
+
+type diskStore struct {
+	opts  DiskOptions
+	mu    sync.Mutex
+	index map[string]diskEntry
+	size  int64
+}
+
+type diskEntry struct {
+	File      string    `json:"file"`
+	CreatedAt time.Time `json:"created_at"`
+	Size      int64     `json:"size"`
+}
+
+func openDiskStore(opts DiskOptions) (*diskStore, error) {
+	if opts.Dir == "" {
+		return nil, errors.New("pokecache: disk cache directory is empty")
+	}
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	d := &diskStore{
+		opts:  opts,
+		index: make(map[string]diskEntry),
+	}
+	data, err := os.ReadFile(filepath.Join(opts.Dir, indexFile))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		// A corrupt index only costs us the cached files, so start over
+		// rather than refusing to open the cache.
+		if json.Unmarshal(data, &d.index) != nil {
+			d.index = make(map[string]diskEntry)
+		}
+	}
+	for key, entry := range d.index {
+		// The size isn't counted yet, so drop expired entries without
+		// remove, which would take theirs off the total.
+		if d.expired(entry) {
+			os.Remove(filepath.Join(opts.Dir, entry.File))
+			delete(d.index, key)
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(opts.Dir, entry.File)); err != nil {
+			delete(d.index, key)
+			continue
+		}
+		d.size += entry.Size
+	}
+	d.evict()
+	return d, d.saveIndex()
+}
+
+func (d *diskStore) get(key string) ([]byte, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	entry, ok := d.index[key]
+	if !ok {
+		return nil, false
+	}
+	if d.expired(entry) {
+		d.remove(key)
+		d.saveIndex()
+		return nil, false
+	}
+	val, err := os.ReadFile(filepath.Join(d.opts.Dir, entry.File))
+	if err != nil {
+		d.remove(key)
+		d.saveIndex()
+		return nil, false
+	}
+	return val, true
+}
+
+func (d *diskStore) add(key string, val []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	sum := sha256.Sum256([]byte(key))
+	name := hex.EncodeToString(sum[:])
+	tmp, err := os.CreateTemp(d.opts.Dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(val)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filepath.Join(d.opts.Dir, name))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if old, ok := d.index[key]; ok {
+		d.size -= old.Size
+	}
+	d.index[key] = diskEntry{
+		File:      name,
+		CreatedAt: time.Now(),
+		Size:      int64(len(val)),
+	}
+	d.size += int64(len(val))
+	d.evict()
+	return d.saveIndex()
+}
+
+func (d *diskStore) keys() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	keys := make([]string, 0, len(d.index))
+	for key, entry := range d.index {
+		if !d.expired(entry) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (d *diskStore) expired(entry diskEntry) bool {
+	return d.opts.TTL > 0 && time.Since(entry.CreatedAt) > d.opts.TTL
+}
+
+// evict removes the oldest entries until the store fits in MaxBytes.
+func (d *diskStore) evict() {
+	if d.opts.MaxBytes <= 0 || d.size <= d.opts.MaxBytes {
+		return
+	}
+	keys := make([]string, 0, len(d.index))
+	for key := range d.index {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return d.index[keys[i]].CreatedAt.Before(d.index[keys[j]].CreatedAt)
+	})
+	for _, key := range keys {
+		if d.size <= d.opts.MaxBytes {
+			return
+		}
+		d.remove(key)
+	}
+}
+
+func (d *diskStore) remove(key string) {
+	entry, ok := d.index[key]
+	if !ok {
+		return
+	}
+	os.Remove(filepath.Join(d.opts.Dir, entry.File))
+	delete(d.index, key)
+	d.size -= entry.Size
+}
+
+func (d *diskStore) saveIndex() error {
+	data, err := json.Marshal(d.index)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(d.opts.Dir, indexFile)
+	if err := os.WriteFile(path+".tmp", data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
@@ -0,0 +1,136 @@
+package pokecache
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDiskSurvivesRestart(t *testing.T) {
+	opts := DiskOptions{Dir: t.TempDir()}
+	cache, err := NewCacheWithDisk(time.Minute, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cache.Add("https://example.com", []byte("testdata"))
+
+	reopened, err := NewCacheWithDisk(time.Minute, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	val, ok := reopened.Get("https://example.com")
+	if !ok {
+		t.Errorf("expected to find key on disk")
+		return
+	}
+	if string(val) != "testdata" {
+		t.Errorf("expected to find value, got %q", val)
+	}
+}
+
+func TestDiskFallthroughAfterReap(t *testing.T) {
+	const baseTime = 5 * time.Millisecond
+	cache, err := NewCacheWithDisk(baseTime, DiskOptions{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cache.Add("https://example.com", []byte("testdata"))
+	time.Sleep(baseTime + 5*time.Millisecond)
+	if _, ok := cache.Get("https://example.com"); !ok {
+		t.Errorf("expected memory miss to fall through to disk")
+	}
+}
+
+func TestDiskTTL(t *testing.T) {
+	opts := DiskOptions{Dir: t.TempDir(), TTL: 5 * time.Millisecond}
+	cache, err := NewCacheWithDisk(time.Minute, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cache.Add("https://example.com", []byte("testdata"))
+	time.Sleep(10 * time.Millisecond)
+
+	reopened, err := NewCacheWithDisk(time.Minute, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := reopened.Get("https://example.com"); ok {
+		t.Errorf("expected expired key to be gone")
+	}
+}
+
+func TestDiskEvictsOldestFirst(t *testing.T) {
+	opts := DiskOptions{Dir: t.TempDir(), MaxBytes: 10}
+	cache, err := NewCacheWithDisk(time.Minute, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cache.Add("first", []byte("12345"))
+	time.Sleep(time.Millisecond)
+	cache.Add("second", []byte("12345"))
+	time.Sleep(time.Millisecond)
+	cache.Add("third", []byte("12345"))
+
+	reopened, err := NewCacheWithDisk(time.Minute, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := reopened.Get("first"); ok {
+		t.Errorf("expected oldest key to be evicted")
+	}
+	for _, key := range []string{"second", "third"} {
+		if _, ok := reopened.Get(key); !ok {
+			t.Errorf("expected to find %s", key)
+		}
+	}
+}
+
+func TestDiskLimitAfterExpiry(t *testing.T) {
+	opts := DiskOptions{Dir: t.TempDir(), TTL: 50 * time.Millisecond, MaxBytes: 10}
+	cache, err := NewCacheWithDisk(time.Minute, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cache.Add("old", []byte("12345"))
+	cache.Add("older", []byte("12345"))
+	time.Sleep(60 * time.Millisecond)
+
+	// Dropping the expired entries mustn't leave room for more than
+	// MaxBytes of new ones.
+	reopened, err := NewCacheWithDisk(time.Minute, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, key := range []string{"first", "second", "third"} {
+		reopened.Add(key, []byte("12345"))
+		time.Sleep(time.Millisecond)
+	}
+	files, err := os.ReadDir(opts.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Two entries fit, plus the index.
+	if len(files) != 3 {
+		t.Errorf("Error - files in the cache. Actual - %d vs Expected - 3", len(files))
+	}
+}
+
+func TestKeysIncludeDisk(t *testing.T) {
+	opts := DiskOptions{Dir: t.TempDir()}
+	cache, err := NewCacheWithDisk(time.Minute, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cache.Add("https://example.com/b", []byte("b"))
+	cache.Add("https://example.com/a", []byte("a"))
+
+	reopened, err := NewCacheWithDisk(time.Minute, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reopened.Add("https://example.com/c", []byte("c"))
+	keys := reopened.Keys()
+	if len(keys) != 3 || keys[0] != "https://example.com/a" || keys[2] != "https://example.com/c" {
+		t.Errorf("expected the keys in memory and on disk, got %v", keys)
+	}
+}
@@ -2,6 +2,7 @@ package pokecache
 
 import (
 	"time"
+	"sort"
 	"sync"
 )
 
@@ -9,6 +10,7 @@ type Cache struct {
 	Entries		map[string]cacheEntry 
 	Interval 	time.Duration
 	Mu			sync.Mutex
+	disk		*diskStore
 }
 
 type cacheEntry struct {
@@ -25,6 +27,18 @@ func NewCache(interval time.Duration) *Cache {
 	return c
 }
 
+// NewCacheWithDisk returns a Cache that also keeps every entry in a directory
+// on disk, so entries survive restarts and memory misses fall through to disk.
+func NewCacheWithDisk(interval time.Duration, opts DiskOptions) (*Cache, error) {
+	disk, err := openDiskStore(opts)
+	if err != nil {
+		return nil, err
+	}
+	c := NewCache(interval)
+	c.disk = disk
+	return c, nil
+}
+
 func (c *Cache) Add(key string, value []byte) {
 	c.Mu.Lock()
 	defer c.Mu.Unlock()
@@ -33,6 +47,10 @@ func (c *Cache) Add(key string, value []byte) {
 		val: value,
 	}
 	c.Entries[key] = cEntry
+	if c.disk != nil {
+		// The disk tier is best effort; the entry is still cached in memory.
+		c.disk.add(key, value)
+	}
 }
 
 func (c *Cache) Get(key string) ([]byte, bool) {
@@ -40,12 +58,43 @@ func (c *Cache) Get(key string) ([]byte, bool) {
 	defer c.Mu.Unlock()
 	cEntry, ok := c.Entries[key]
 	if !ok {
+		if c.disk != nil {
+			val, ok := c.disk.get(key)
+			if ok {
+				c.Entries[key] = cacheEntry{
+					createdAt: time.Now(),
+					val: val,
+				}
+				return val, true
+			}
+		}
 		var b []byte
 		return b, false
 	}
 	return cEntry.val, true
 }
 
+// Keys returns the keys of every entry in memory or on disk, sorted.
+func (c *Cache) Keys() []string {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+	seen := make(map[string]bool)
+	keys := []string{}
+	for key := range c.Entries {
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	if c.disk != nil {
+		for _, key := range c.disk.keys() {
+			if !seen[key] {
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func (c *Cache) reapLoop() {
 	ticker := time.NewTicker(c.Interval)
 	defer ticker.Stop()
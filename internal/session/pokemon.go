@@ -0,0 +1,211 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/stats"
+)
+
+const MaxPartySize = 6
+
+// Pokemon caught before levels were tracked are treated as this level.
+const DefaultLevel = 50
+
+// MaxFriendship is the most friendship a pokemon can have for its trainer.
+const MaxFriendship = 255
+
+// DexEntry records what the trainer knows about a species.
+type DexEntry struct {
+	Name   string `json:"name"`
+	Seen   bool   `json:"seen,omitempty"`
+	Caught bool   `json:"caught,omitempty"`
+}
+
+// CaughtPokemon is one pokemon the trainer owns.
+type CaughtPokemon struct {
+	ID         int             `json:"id"`
+	Nickname   string          `json:"nickname,omitempty"`
+	Pokemon    pokeapi.Pokemon `json:"pokemon"`
+	CaughtAt   time.Time       `json:"caught_at"`
+	Level      int             `json:"level,omitempty"`
+	Experience int             `json:"experience,omitempty"`
+	GrowthRate string          `json:"growth_rate,omitempty"`
+	NatureName string          `json:"nature,omitempty"`
+	IVs        stats.Set       `json:"ivs"`
+	EVs        stats.Set       `json:"evs"`
+	Location   string          `json:"location,omitempty"`
+	Friendship int             `json:"friendship,omitempty"`
+}
+
+// DisplayName is the pokemon's nickname, or its species name if it doesn't
+// have one.
+func (p CaughtPokemon) DisplayName() string {
+	if p.Nickname != "" {
+		return p.Nickname
+	}
+	return p.Pokemon.Name
+}
+
+// Label identifies an owned pokemon in listings, e.g. "#3 sparky (pikachu)".
+func (p CaughtPokemon) Label() string {
+	if p.Nickname != "" {
+		return fmt.Sprintf("#%d %s (%s)", p.ID, p.Nickname, p.Pokemon.Name)
+	}
+	return fmt.Sprintf("#%d %s", p.ID, p.Pokemon.Name)
+}
+
+// SpeciesName is the name of the pokemon's species, which the pokedex is
+// keyed by. It falls back to the pokemon's name when the species is missing.
+func (p CaughtPokemon) SpeciesName() string {
+	if p.Pokemon.Species.Name != "" {
+		return p.Pokemon.Species.Name
+	}
+	return p.Pokemon.Name
+}
+
+// CurrentLevel is the pokemon's level, or DefaultLevel if it isn't known.
+func (p CaughtPokemon) CurrentLevel() int {
+	if p.Level <= 0 {
+		return DefaultLevel
+	}
+	return p.Level
+}
+
+func (p CaughtPokemon) Nature() stats.Nature {
+	n, _ := stats.NatureByName(p.NatureName)
+	return n
+}
+
+// Stats computes the pokemon's actual stats at its current level.
+func (p CaughtPokemon) Stats() stats.Set {
+	return stats.Compute(stats.Base(p.Pokemon), p.IVs, p.EVs, p.CurrentLevel(), p.Nature())
+}
+
+// NewCaught rolls the IVs and nature of a pokemon caught at level in the
+// current location.
+func (s *Session) NewCaught(poke pokeapi.Pokemon, species pokeapi.PokemonSpecies, level int) CaughtPokemon {
+	caught := CaughtPokemon{
+		Pokemon:    poke,
+		CaughtAt:   time.Now(),
+		Level:      level,
+		GrowthRate: species.GrowthRate.Name,
+		NatureName: stats.RandomNature(s.RNG).Name,
+		IVs:        stats.RandomIVs(s.RNG),
+		Location:   s.Location,
+		Friendship: species.BaseHappiness,
+	}
+	if exp, err := stats.Experience(caught.GrowthRate, caught.CurrentLevel()); err == nil {
+		caught.Experience = exp
+	}
+	return caught
+}
+
+// Befriend raises the pokemon's friendship by n, up to MaxFriendship.
+func (p *CaughtPokemon) Befriend(n int) {
+	p.Friendship = min(p.Friendship+n, MaxFriendship)
+}
+
+// AddOwned gives a newly caught pokemon an ID and puts it in the party, or
+// in the box if the party is full.
+func (s *Session) AddOwned(caught CaughtPokemon) CaughtPokemon {
+	if s.Owned == nil {
+		s.Owned = make(map[int]CaughtPokemon)
+	}
+	s.NextID = max(s.NextID, 1)
+	caught.ID = s.NextID
+	s.NextID++
+	s.Owned[caught.ID] = caught
+	if len(s.Party) < MaxPartySize {
+		s.Party = append(s.Party, caught.ID)
+	}
+	s.MarkCaught(caught.SpeciesName())
+	return caught
+}
+
+// MarkCaught records species, a species name rather than a pokemon name
+// like giratina-altered, as caught.
+func (s *Session) MarkCaught(species string) {
+	s.MarkSeen(species)
+	entry := s.Pokedex[species]
+	entry.Caught = true
+	s.Pokedex[species] = entry
+}
+
+// MarkSeen records species, a species name, as seen.
+func (s *Session) MarkSeen(species string) {
+	if s.Pokedex == nil {
+		s.Pokedex = make(map[string]DexEntry)
+	}
+	entry := s.Pokedex[species]
+	entry.Name = species
+	entry.Seen = true
+	s.Pokedex[species] = entry
+}
+
+// FindOwned looks up an owned pokemon by ID, nickname or species name.
+func (s *Session) FindOwned(ref string) (CaughtPokemon, error) {
+	if id, err := strconv.Atoi(strings.TrimPrefix(ref, "#")); err == nil {
+		caught, ok := s.Owned[id]
+		if !ok {
+			return CaughtPokemon{}, fmt.Errorf("You don't have a Pokemon with ID %d.", id)
+		}
+		return caught, nil
+	}
+	var matches []CaughtPokemon
+	for _, caught := range s.OwnedByID() {
+		if strings.EqualFold(caught.Nickname, ref) {
+			return caught, nil
+		}
+		if caught.Pokemon.Name == ref {
+			matches = append(matches, caught)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return CaughtPokemon{}, errors.New("you have not caught that pokemon")
+	case 1:
+		return matches[0], nil
+	}
+	ids := make([]string, 0, len(matches))
+	for _, caught := range matches {
+		ids = append(ids, strconv.Itoa(caught.ID))
+	}
+	return CaughtPokemon{}, fmt.Errorf("You have %d %s - choose one by ID: %s.", len(matches), ref, strings.Join(ids, ", "))
+}
+
+func (s *Session) InParty(id int) bool {
+	for _, member := range s.Party {
+		if member == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Session) OwnedByID() []CaughtPokemon {
+	owned := make([]CaughtPokemon, 0, len(s.Owned))
+	for _, caught := range s.Owned {
+		owned = append(owned, caught)
+	}
+	sort.Slice(owned, func(i, j int) bool {
+		return owned[i].ID < owned[j].ID
+	})
+	return owned
+}
+
+// Boxed returns the owned pokemon that aren't in the party, in ID order.
+func (s *Session) Boxed() []CaughtPokemon {
+	boxed := []CaughtPokemon{}
+	for _, caught := range s.OwnedByID() {
+		if !s.InParty(caught.ID) {
+			boxed = append(boxed, caught)
+		}
+	}
+	return boxed
+}
@@ -0,0 +1,228 @@
+package session
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/stats"
+)
+
+func caughtNamed(name string) CaughtPokemon {
+	return CaughtPokemon{Pokemon: pokeapi.Pokemon{Name: name}}
+}
+
+func TestCaughtStats(t *testing.T) {
+	cases := []struct {
+		caught   CaughtPokemon
+		expected stats.Set
+	}{
+		{
+			caught:   CaughtPokemon{Level: 100},
+			expected: stats.Set{HP: 180, Attack: 115, Defense: 85, SpAttack: 105, SpDefense: 105, Speed: 185},
+		},
+		{
+			// No level means the default of 50.
+			caught:   CaughtPokemon{},
+			expected: stats.Set{HP: 95, Attack: 60, Defense: 45, SpAttack: 55, SpDefense: 55, Speed: 95},
+		},
+		{
+			caught: CaughtPokemon{
+				Level:      50,
+				NatureName: "timid",
+				IVs:        stats.Set{HP: 31, Speed: 31},
+				EVs:        stats.Set{Speed: 252},
+			},
+			expected: stats.Set{HP: 110, Attack: 54, Defense: 45, SpAttack: 55, SpDefense: 55, Speed: 156},
+		},
+	}
+	pikachu := pokeapi.Pokemon{Name: "pikachu"}
+	for i, base := range []int{35, 55, 40, 50, 50, 90} {
+		pikachu.Stats = append(pikachu.Stats, pokeapi.PokemonStat{BaseStat: base, Stat: pokeapi.NamedAPIResource{Name: stats.Names[i]}})
+	}
+	for _, c := range cases {
+		c.caught.Pokemon = pikachu
+		if actual := c.caught.Stats(); actual != c.expected {
+			t.Errorf("Error - %+v. Actual - %+v vs Expected - %+v", c.caught, actual, c.expected)
+		}
+	}
+}
+
+func TestNewCaught(t *testing.T) {
+	s := &Session{Location: "viridian-forest-area"}
+	s.Reseed(1)
+	species := pokeapi.PokemonSpecies{GrowthRate: pokeapi.NamedAPIResource{Name: "medium"}}
+	caught := s.NewCaught(pokeapi.Pokemon{Name: "pikachu"}, species, 5)
+	if caught.Level != 5 || caught.Experience != 125 || caught.GrowthRate != "medium" {
+		t.Errorf("Error - level and experience. Actual - %+v", caught)
+	}
+	if _, ok := stats.NatureByName(caught.NatureName); !ok {
+		t.Errorf("Error - %q is not a nature", caught.NatureName)
+	}
+	if caught.Location != "viridian-forest-area" {
+		t.Errorf("Error - location. Actual - %v vs Expected - viridian-forest-area", caught.Location)
+	}
+}
+
+func TestReseedRepeatsRolls(t *testing.T) {
+	s := &Session{}
+	species := pokeapi.PokemonSpecies{GrowthRate: pokeapi.NamedAPIResource{Name: "medium"}}
+	poke := pokeapi.Pokemon{Name: "pikachu"}
+
+	s.Reseed(42)
+	first := s.NewCaught(poke, species, 5)
+	s.Reseed(42)
+	second := s.NewCaught(poke, species, 5)
+	if first.IVs != second.IVs || first.NatureName != second.NatureName {
+		t.Errorf("Error - same seed. Actual - %+v %s vs Expected - %+v %s", second.IVs, second.NatureName, first.IVs, first.NatureName)
+	}
+}
+
+func TestAddOwnedFillsPartyThenBox(t *testing.T) {
+	s := &Session{}
+	for i := 0; i < MaxPartySize+2; i++ {
+		s.AddOwned(caughtNamed("pikachu"))
+	}
+	if len(s.Owned) != MaxPartySize+2 {
+		t.Fatalf("Error - owned count. Actual - %v vs Expected - %v", len(s.Owned), MaxPartySize+2)
+	}
+	if len(s.Party) != MaxPartySize {
+		t.Errorf("Error - party size. Actual - %v vs Expected - %v", len(s.Party), MaxPartySize)
+	}
+	boxed := s.Boxed()
+	if len(boxed) != 2 || boxed[0].ID != 7 || boxed[1].ID != 8 {
+		t.Errorf("Error - boxed. Actual - %+v", boxed)
+	}
+	if !s.Pokedex["pikachu"].Caught {
+		t.Errorf("expected pikachu to be marked caught")
+	}
+}
+
+func TestFindOwned(t *testing.T) {
+	s := &Session{}
+	s.AddOwned(caughtNamed("pikachu"))
+	s.AddOwned(caughtNamed("pikachu"))
+	s.AddOwned(caughtNamed("bulbasaur"))
+	sparky := s.Owned[2]
+	sparky.Nickname = "Sparky"
+	s.Owned[2] = sparky
+
+	cases := []struct {
+		ref      string
+		expected int
+	}{
+		{"1", 1},
+		{"#2", 2},
+		{"sparky", 2},
+		{"bulbasaur", 3},
+	}
+	for _, tc := range cases {
+		actual, err := s.FindOwned(tc.ref)
+		if err != nil {
+			t.Errorf("Error - %q: %v", tc.ref, err)
+			continue
+		}
+		if actual.ID != tc.expected {
+			t.Errorf("Error - %q. Actual - %v vs Expected - %v", tc.ref, actual.ID, tc.expected)
+		}
+	}
+	for _, ref := range []string{"pikachu", "4", "charmander"} {
+		if _, err := s.FindOwned(ref); err == nil {
+			t.Errorf("expected an error for %q", ref)
+		}
+	}
+}
+
+func TestMarkSeenKeepsCatches(t *testing.T) {
+	s := &Session{}
+	s.MarkCaught("pikachu")
+	s.MarkSeen("pikachu")
+	if entry := s.Pokedex["pikachu"]; !entry.Seen || !entry.Caught {
+		t.Errorf("Error - pikachu. Actual - %+v", entry)
+	}
+}
+
+func TestPokedexCountsSpecies(t *testing.T) {
+	s := &Session{}
+	giratina := caughtNamed("giratina-altered")
+	giratina.Pokemon.Species.Name = "giratina"
+	s.AddOwned(giratina)
+	if !s.Pokedex["giratina"].Caught {
+		t.Errorf("expected giratina to be marked caught, got %+v", s.Pokedex)
+	}
+	if _, ok := s.Pokedex["giratina-altered"]; ok {
+		t.Errorf("expected no entry for the form giratina-altered")
+	}
+}
+
+func TestSpeciesOf(t *testing.T) {
+	lookups := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pokemon-species/":
+			w.Write([]byte(`{"count":2,"next":null,"results":[{"name":"giratina"},{"name":"pikachu"}]}`))
+		case "/pokemon/lycanroc-midday/":
+			lookups++
+			w.Write([]byte(`{"name":"lycanroc-midday","species":{"name":"lycanroc"}}`))
+		default:
+			lookups++
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	s := New(pokeapi.NewClient(srv.URL, nil, nil))
+	ctx := context.Background()
+	cases := []struct {
+		name     string
+		expected string
+	}{
+		{name: "pikachu", expected: "pikachu"},
+		{name: "giratina-altered", expected: "giratina"},
+		{name: "lycanroc-midday", expected: "lycanroc"},
+		{name: "lycanroc-midday", expected: "lycanroc"},
+	}
+	for _, c := range cases {
+		species, err := s.SpeciesOf(ctx, c.name)
+		if err != nil || species != c.expected {
+			t.Errorf("Error - species of %s. Actual - %q, %v vs Expected - %s", c.name, species, err, c.expected)
+		}
+	}
+	// Only lycanroc isn't on the species list, and it's remembered.
+	if lookups != 1 {
+		t.Errorf("Error - pokemon looked up. Actual - %d vs Expected - 1", lookups)
+	}
+
+	s.SeePokemon(ctx, "missingno")
+	if _, ok := s.Pokedex["missingno"]; ok {
+		t.Errorf("expected a pokemon without a species not to be marked seen")
+	}
+}
+
+func TestResolveName(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pokemon/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"count":3,"next":null,"results":[{"name":"pikachu"},{"name":"pichu"},{"name":"raichu"}]}`))
+	}))
+	defer srv.Close()
+	c := New(pokeapi.NewClient(srv.URL, nil, nil))
+	ctx := context.Background()
+	if name, err := c.ResolveName(ctx, "pokemon", "pik"); err != nil || name != "pikachu" {
+		t.Errorf("Error - Resolve pik: Actual - %s, %v vs Expected - pikachu", name, err)
+	}
+	expected := `Unknown pokemon "pikahcu" - did you mean pikachu?`
+	if _, err := c.ResolveName(ctx, "pokemon", "pikahcu"); err == nil || err.Error() != expected {
+		t.Errorf("Error - Resolve pikahcu: Actual - %v vs Expected - %s", err, expected)
+	}
+	if name, err := c.ResolveName(ctx, "pokemon", "25"); err != nil || name != "25" {
+		t.Errorf("Error - Resolve 25: Actual - %s, %v vs Expected - 25", name, err)
+	}
+	// Without an index the name is passed through for the API to judge.
+	if name, err := c.ResolveName(ctx, "location-area", "canalave-city"); err != nil || name != "canalave-city" {
+		t.Errorf("Error - Resolve without an index: Actual - %s, %v", name, err)
+	}
+}
@@ -0,0 +1,180 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/smwalke83/pokedex/internal/inventory"
+	"github.com/smwalke83/pokedex/internal/wild"
+)
+
+// Version 2 split the pokedex into species entries and owned pokemon with
+// IDs, and made the party a list of IDs. Version 3 added money.
+const saveVersion = 3
+
+type saveFile struct {
+	Version     int             `json:"version"`
+	SavedAt     time.Time       `json:"saved_at"`
+	Next        string          `json:"next"`
+	Previous    *string         `json:"previous"`
+	Pokedex     []DexEntry      `json:"pokedex"`
+	Owned       []CaughtPokemon `json:"owned"`
+	NextID      int             `json:"next_id"`
+	Bag         inventory.Bag   `json:"bag"`
+	Money       int             `json:"money"`
+	Party       []int           `json:"party,omitempty"`
+	Location    string          `json:"location,omitempty"`
+	GameVersion string          `json:"game_version,omitempty"`
+	Wild        *wild.Pokemon   `json:"wild,omitempty"`
+}
+
+// saveFileV1 overrides the fields whose format changed in version 2.
+type saveFileV1 struct {
+	saveFile
+	Pokedex []CaughtPokemon `json:"pokedex"`
+	Party   []string        `json:"party,omitempty"`
+}
+
+// DefaultSavePath is where the save file lives unless another is chosen.
+func DefaultSavePath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "pokedex_save.json"
+	}
+	return filepath.Join(dir, "pokedex", "save.json")
+}
+
+// DefaultHistoryPath is the command history file next to the default save.
+func DefaultHistoryPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ".pokedex_history"
+	}
+	return filepath.Join(dir, "pokedex", "history")
+}
+
+// Save writes the session state to path, replacing any earlier save.
+func (s *Session) Save(path string) error {
+	sf := saveFile{
+		Version:     saveVersion,
+		SavedAt:     time.Now(),
+		Next:        s.Next,
+		Previous:    s.Previous,
+		Pokedex:     make([]DexEntry, 0, len(s.Pokedex)),
+		Owned:       s.OwnedByID(),
+		NextID:      s.NextID,
+		Bag:         s.Bag,
+		Money:       s.Money,
+		Party:       s.Party,
+		Location:    s.Location,
+		GameVersion: s.GameVersion,
+		Wild:        s.Wild,
+	}
+	for _, entry := range s.Pokedex {
+		sf.Pokedex = append(sf.Pokedex, entry)
+	}
+	sort.Slice(sf.Pokedex, func(i, j int) bool {
+		return sf.Pokedex[i].Name < sf.Pokedex[j].Name
+	})
+	data, err := json.MarshalIndent(sf, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Load restores the session state from a save file, migrating saves from
+// older versions.
+func (s *Session) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return fmt.Errorf("%s is not a valid save file: %w", path, err)
+	}
+	if header.Version < 1 || header.Version > saveVersion {
+		return fmt.Errorf("%s has unsupported save version %d", path, header.Version)
+	}
+	var sf saveFile
+	if header.Version == 1 {
+		var v1 saveFileV1
+		if err := json.Unmarshal(data, &v1); err != nil {
+			return fmt.Errorf("%s is not a valid save file: %w", path, err)
+		}
+		sf = migrateV1(v1)
+	} else if err := json.Unmarshal(data, &sf); err != nil {
+		return fmt.Errorf("%s is not a valid save file: %w", path, err)
+	}
+	s.Next = sf.Next
+	s.Previous = sf.Previous
+	s.Party = sf.Party
+	s.Location = sf.Location
+	s.GameVersion = sf.GameVersion
+	s.Wild = sf.Wild
+	s.NextID = sf.NextID
+	s.Pokedex = make(map[string]DexEntry, len(sf.Pokedex))
+	for _, entry := range sf.Pokedex {
+		s.Pokedex[entry.Name] = entry
+	}
+	s.Owned = make(map[int]CaughtPokemon, len(sf.Owned))
+	for _, caught := range sf.Owned {
+		s.Owned[caught.ID] = caught
+		// Older saves marked forms such as giratina-altered as caught
+		// rather than their species, so fold those entries into it.
+		if form := caught.Pokemon.Name; form != caught.SpeciesName() {
+			delete(s.Pokedex, form)
+		}
+		s.MarkCaught(caught.SpeciesName())
+	}
+	// Version 1 saves may predate the bag, and those start with a fresh
+	// one. Later saves always had a bag, and only left it out when it was
+	// empty.
+	s.Bag = sf.Bag
+	if s.Bag == nil && header.Version == 1 {
+		s.Bag = inventory.StarterBag()
+	} else if s.Bag == nil {
+		s.Bag = inventory.Bag{}
+	}
+	s.Money = sf.Money
+	if header.Version < 3 {
+		s.Money = StartingMoney
+	}
+	return nil
+}
+
+// migrateV1 numbers the pokemon in a version 1 save, which held at most
+// one of each species keyed by name, and rewrites the party as IDs.
+func migrateV1(v1 saveFileV1) saveFile {
+	sf := v1.saveFile
+	ids := make(map[string]int, len(v1.Pokedex))
+	for i, caught := range v1.Pokedex {
+		caught.ID = i + 1
+		ids[caught.Pokemon.Name] = caught.ID
+		sf.Owned = append(sf.Owned, caught)
+		sf.Pokedex = append(sf.Pokedex, DexEntry{Name: caught.SpeciesName(), Seen: true, Caught: true})
+	}
+	sf.NextID = len(v1.Pokedex) + 1
+	for _, name := range v1.Party {
+		if id, ok := ids[name]; ok {
+			sf.Party = append(sf.Party, id)
+		}
+	}
+	return sf
+}
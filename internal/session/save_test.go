@@ -0,0 +1,177 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/smwalke83/pokedex/internal/inventory"
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/wild"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	previous := "https://pokeapi.co/api/v2/location-area?offset=0&limit=20"
+	caughtAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	original := &Session{
+		Next:        "https://pokeapi.co/api/v2/location-area?offset=40&limit=20",
+		Previous:    &previous,
+		Bag:         inventory.Bag{"great-ball": 2},
+		Money:       1234,
+		Location:    "viridian-forest-area",
+		GameVersion: "red",
+		Wild:        &wild.Pokemon{Name: "caterpie", Level: 4},
+		Pokedex: map[string]DexEntry{
+			"pikachu": {Name: "pikachu", Seen: true, Caught: true},
+		},
+		Owned: map[int]CaughtPokemon{
+			3: {
+				ID:         3,
+				Nickname:   "Sparky",
+				Pokemon:    pokeapi.Pokemon{ID: 25, Name: "pikachu", Height: 4},
+				CaughtAt:   caughtAt,
+				Friendship: 120,
+			},
+		},
+		NextID: 4,
+		Party:  []int{3},
+	}
+	if err := original.Save(path); err != nil {
+		t.Fatalf("unexpected error saving: %v", err)
+	}
+
+	restored := &Session{}
+	if err := restored.Load(path); err != nil {
+		t.Fatalf("unexpected error loading: %v", err)
+	}
+	if restored.Next != original.Next {
+		t.Errorf("Next: Actual - %s vs Expected - %s", restored.Next, original.Next)
+	}
+	if restored.Previous == nil || *restored.Previous != previous {
+		t.Errorf("Previous: Actual - %v vs Expected - %s", restored.Previous, previous)
+	}
+	if !restored.Pokedex["pikachu"].Caught {
+		t.Errorf("expected pikachu to be caught in the restored pokedex")
+	}
+	caught, ok := restored.Owned[3]
+	if !ok {
+		t.Fatalf("expected pikachu in restored pokemon")
+	}
+	if caught.Nickname != "Sparky" || restored.NextID != 4 || len(restored.Party) != 1 || restored.Party[0] != 3 {
+		t.Errorf("unexpected IDs: %+v, next %d, party %v", caught, restored.NextID, restored.Party)
+	}
+	if caught.Pokemon.ID != 25 || caught.Pokemon.Height != 4 {
+		t.Errorf("unexpected pokemon data: %+v", caught.Pokemon)
+	}
+	if !caught.CaughtAt.Equal(caughtAt) {
+		t.Errorf("CaughtAt: Actual - %v vs Expected - %v", caught.CaughtAt, caughtAt)
+	}
+	if restored.Location != "viridian-forest-area" || restored.GameVersion != "red" {
+		t.Errorf("unexpected location: %s (%s)", restored.Location, restored.GameVersion)
+	}
+	if restored.Wild == nil || *restored.Wild != *original.Wild {
+		t.Errorf("Wild: Actual - %v vs Expected - %v", restored.Wild, original.Wild)
+	}
+	if restored.Bag.Count("great-ball") != 2 || restored.Bag.Count("poke-ball") != 0 {
+		t.Errorf("unexpected bag: %v", restored.Bag)
+	}
+	if restored.Money != 1234 || caught.Friendship != 120 {
+		t.Errorf("Money and friendship: Actual - %d, %d vs Expected - 1234, 120", restored.Money, caught.Friendship)
+	}
+}
+
+func TestSaveLoadEmptyBag(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	original := &Session{Bag: inventory.Bag{"poke-ball": 1}}
+	if err := original.Bag.Use("poke-ball"); err != nil {
+		t.Fatal(err)
+	}
+	if err := original.Save(path); err != nil {
+		t.Fatalf("unexpected error saving: %v", err)
+	}
+	loaded := &Session{}
+	if err := loaded.Load(path); err != nil {
+		t.Fatalf("unexpected error loading: %v", err)
+	}
+	if loaded.Bag == nil || len(loaded.Bag) != 0 {
+		t.Errorf("Bag: Actual - %v vs Expected - empty", loaded.Bag)
+	}
+}
+
+func TestLoadRejectsNewerVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := os.WriteFile(path, []byte(`{"version": 999}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := (&Session{}).Load(path); err == nil {
+		t.Errorf("expected an error for an unsupported save version")
+	}
+}
+
+func TestLoadMigratesVersion1(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	v1 := `{
+		"version": 1,
+		"pokedex": [
+			{"pokemon": {"name": "bulbasaur"}, "level": 5},
+			{"pokemon": {"name": "pikachu"}, "level": 7}
+		],
+		"party": ["pikachu"]
+	}`
+	if err := os.WriteFile(path, []byte(v1), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c := &Session{}
+	if err := c.Load(path); err != nil {
+		t.Fatalf("unexpected error loading: %v", err)
+	}
+	if len(c.Owned) != 2 || c.Owned[1].Pokemon.Name != "bulbasaur" || c.Owned[2].Pokemon.Name != "pikachu" {
+		t.Errorf("unexpected owned pokemon: %+v", c.Owned)
+	}
+	if c.Owned[2].Level != 7 {
+		t.Errorf("Level: Actual - %d vs Expected - 7", c.Owned[2].Level)
+	}
+	if len(c.Party) != 1 || c.Party[0] != 2 {
+		t.Errorf("Party: Actual - %v vs Expected - [2]", c.Party)
+	}
+	if c.NextID != 3 || !c.Pokedex["bulbasaur"].Caught || !c.Pokedex["pikachu"].Caught {
+		t.Errorf("unexpected pokedex: %+v, next %d", c.Pokedex, c.NextID)
+	}
+	// Saves from before money start with what a new trainer has.
+	if c.Money != StartingMoney {
+		t.Errorf("Money: Actual - %d vs Expected - %d", c.Money, StartingMoney)
+	}
+}
+
+func TestLoadFoldsFormEntries(t *testing.T) {
+	saves := map[string]string{
+		"version 1": `{
+			"version": 1,
+			"pokedex": [{"pokemon": {"name": "giratina-altered", "species": {"name": "giratina"}}}]
+		}`,
+		"version 2": `{
+			"version": 2,
+			"pokedex": [
+				{"name": "giratina-altered", "seen": true, "caught": true},
+				{"name": "pikachu", "seen": true}
+			],
+			"owned": [{"id": 1, "pokemon": {"name": "giratina-altered", "species": {"name": "giratina"}}}],
+			"next_id": 2
+		}`,
+	}
+	for version, data := range saves {
+		path := filepath.Join(t.TempDir(), "save.json")
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		c := &Session{}
+		if err := c.Load(path); err != nil {
+			t.Fatalf("unexpected error loading the %s save: %v", version, err)
+		}
+		if _, ok := c.Pokedex["giratina-altered"]; ok || !c.Pokedex["giratina"].Caught {
+			t.Errorf("Error - %s pokedex. Actual - %+v vs Expected - giratina caught, no giratina-altered", version, c.Pokedex)
+		}
+	}
+}
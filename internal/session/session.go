@@ -0,0 +1,163 @@
+// Package session holds the state of a pokedex session: the trainer's
+// pokedex, pokemon, bag and position on the map, along with the API client
+// and random number generator that commands share.
+package session
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/smwalke83/pokedex/internal/fuzzy"
+	"github.com/smwalke83/pokedex/internal/inventory"
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/typechart"
+	"github.com/smwalke83/pokedex/internal/wild"
+)
+
+// StartingMoney is what a new trainer has to spend in shops.
+const StartingMoney = 3000
+
+type Session struct {
+	Client *pokeapi.Client
+	// RNG is the source of every random mechanic, so that a seed and a
+	// command script always play out the same way. Change it with Reseed.
+	RNG  *rand.Rand
+	Seed int64
+	// Timeout limits how long a single command may run; 0 disables it.
+	Timeout  time.Duration
+	SavePath string
+	Autosave bool
+	// HistoryPath is where interactive sessions keep their command
+	// history; empty keeps it in memory only.
+	HistoryPath string
+	typeData    []pokeapi.Type
+	indexes     map[string]*fuzzy.Index
+	species     map[string]string
+
+	Next     string
+	Previous *string
+	// Areas are the location areas on the map page last shown.
+	Areas       []string
+	Pokedex     map[string]DexEntry
+	Owned       map[int]CaughtPokemon
+	NextID      int
+	Bag         inventory.Bag
+	Money       int
+	Party       []int
+	Location    string
+	GameVersion string
+	Wild        *wild.Pokemon
+}
+
+// New returns a session for a new trainer, seeded from the clock.
+func New(client *pokeapi.Client) *Session {
+	s := &Session{
+		Client:  client,
+		Pokedex: make(map[string]DexEntry),
+		Owned:   make(map[int]CaughtPokemon),
+		Bag:     inventory.StarterBag(),
+		Money:   StartingMoney,
+	}
+	s.Reseed(time.Now().UnixNano())
+	return s
+}
+
+// Reseed restarts the random number generator from seed.
+func (s *Session) Reseed(seed int64) {
+	s.Seed = seed
+	s.RNG = rand.New(rand.NewSource(seed))
+}
+
+// TypeChart builds the type chart for generation gen, fetching the type
+// data the first time it's needed.
+func (s *Session) TypeChart(ctx context.Context, gen int) (*typechart.Chart, error) {
+	if s.typeData == nil {
+		types := make([]pokeapi.Type, 0, len(typechart.Types))
+		for _, name := range typechart.Types {
+			t, err := s.Client.GetType(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			types = append(types, t)
+		}
+		s.typeData = types
+	}
+	return typechart.New(s.typeData, gen), nil
+}
+
+// SpeciesOf returns the species of the pokemon called name, which differs
+// from name for forms such as giratina-altered. The pokedex counts species.
+// Forms are named after their species, so the species is the longest part of
+// name found in the species list, which is fetched once; only pokemon that
+// don't fit that pattern are looked up one by one.
+func (s *Session) SpeciesOf(ctx context.Context, name string) (string, error) {
+	if s.species == nil {
+		s.species = make(map[string]string)
+		if ix, err := s.Index(ctx, "pokemon-species"); err == nil {
+			for _, species := range ix.Names() {
+				s.species[species] = species
+			}
+		}
+	}
+	for prefix := name; ; {
+		if species, ok := s.species[prefix]; ok {
+			return species, nil
+		}
+		i := strings.LastIndex(prefix, "-")
+		if i < 0 {
+			break
+		}
+		prefix = prefix[:i]
+	}
+	poke, err := s.Client.GetPokemon(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	s.species[name] = poke.Species.Name
+	return poke.Species.Name, nil
+}
+
+// SeePokemon marks the species of the pokemon called name as seen. It is
+// best effort: if the species can't be found the pokemon isn't marked, since
+// that shouldn't fail whatever showed the pokemon.
+func (s *Session) SeePokemon(ctx context.Context, name string) {
+	if species, err := s.SpeciesOf(ctx, name); err == nil {
+		s.MarkSeen(species)
+	}
+}
+
+// ResolveName turns a name typed for a resource, such as "pokemon" or
+// "location-area", into the name the API knows: itself, the one name it is
+// a prefix of, or an error suggesting the closest names. If the index can't
+// be fetched the name is used as typed and the API has the final say. IDs
+// such as 25 are passed through too, since the API accepts them as names.
+func (s *Session) ResolveName(ctx context.Context, resource, name string) (string, error) {
+	if _, err := strconv.Atoi(name); err == nil {
+		return name, nil
+	}
+	ix, err := s.Index(ctx, resource)
+	if err != nil {
+		return name, nil
+	}
+	return ix.Lookup(name)
+}
+
+// Index returns the index of every name on a list endpoint, such as
+// "pokemon" or "location-area", fetching it the first time it's needed.
+func (s *Session) Index(ctx context.Context, resource string) (*fuzzy.Index, error) {
+	if ix, ok := s.indexes[resource]; ok {
+		return ix, nil
+	}
+	names, err := s.Client.ListNames(ctx, resource)
+	if err != nil {
+		return nil, err
+	}
+	if s.indexes == nil {
+		s.indexes = make(map[string]*fuzzy.Index)
+	}
+	s.indexes[resource] = fuzzy.NewIndex(strings.ReplaceAll(resource, "-", " "), names)
+	return s.indexes[resource], nil
+}
@@ -0,0 +1,71 @@
+// Package shop adds the buy command, which spends the money won in battles
+// on items. It registers the command with command.Default when imported, so
+// the REPL picks it up without knowing about this package.
+package shop
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/smwalke83/pokedex/internal/command"
+	"github.com/smwalke83/pokedex/internal/inventory"
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/session"
+)
+
+func init() {
+	command.Register(command.New(command.Spec{
+		Name:        "buy",
+		Category:    command.Collection,
+		Usage:       "buy <item> [count]",
+		Description: "Buy items, such as balls and evolution stones, with the money won in battles",
+		Args: []command.Arg{
+			{Name: "item", Description: "item to buy", Required: true},
+			{Name: "count", Description: "how many to buy, 1 by default"},
+		},
+		Examples: []string{"buy thunder-stone", "buy great-ball 5"},
+	}, commandBuy))
+}
+
+func commandBuy(ctx context.Context, c *session.Session, args []string, _ map[string]string) error {
+	count := 1
+	if len(args) > 1 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			return fmt.Errorf("Invalid command - %q is not a number of items to buy.", args[1])
+		}
+		count = n
+	}
+	name, err := c.ResolveName(ctx, "item", args[0])
+	if err != nil {
+		return err
+	}
+	item, err := c.Client.GetItem(ctx, name)
+	if err != nil {
+		return err
+	}
+	if err := Buy(c, item, count); err != nil {
+		return err
+	}
+	fmt.Printf("You bought %d %s for %d. You have %d left.\n", count, item.DisplayName(), item.Cost*count, c.Money)
+	return nil
+}
+
+// Buy puts count of item in the bag at the price PokeAPI lists for it.
+// Items without a price aren't sold.
+func Buy(c *session.Session, item pokeapi.Item, count int) error {
+	if item.Cost == 0 {
+		return fmt.Errorf("%s isn't sold in shops.", item.DisplayName())
+	}
+	total := item.Cost * count
+	if total > c.Money {
+		return fmt.Errorf("That costs %d, but you only have %d.", total, c.Money)
+	}
+	if c.Bag == nil {
+		c.Bag = inventory.Bag{}
+	}
+	c.Money -= total
+	c.Bag.Add(item.Name, count)
+	return nil
+}
@@ -0,0 +1,39 @@
+package shop
+
+import (
+	"testing"
+
+	"github.com/smwalke83/pokedex/internal/command"
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/session"
+)
+
+func TestBuy(t *testing.T) {
+	c := &session.Session{Money: 3500}
+	stone := pokeapi.Item{Name: "thunder-stone", Cost: 3000}
+	if err := Buy(c, stone, 1); err != nil {
+		t.Fatal(err)
+	}
+	if c.Money != 500 || c.Bag.Count("thunder-stone") != 1 {
+		t.Errorf("Error - after buying. Money - %d, Bag - %v", c.Money, c.Bag)
+	}
+	if err := Buy(c, stone, 1); err == nil {
+		t.Errorf("expected an error buying without enough money")
+	}
+	if err := Buy(c, pokeapi.Item{Name: "master-ball"}, 1); err == nil {
+		t.Errorf("expected an error buying an item without a price")
+	}
+	if c.Money != 500 || c.Bag.Count("thunder-stone") != 1 {
+		t.Errorf("Error - failed purchases changed the trainer. Money - %d, Bag - %v", c.Money, c.Bag)
+	}
+}
+
+func TestRegistered(t *testing.T) {
+	cmd, ok := command.Default.Lookup("buy")
+	if !ok {
+		t.Fatalf("expected importing the shop to register buy")
+	}
+	if cmd.Spec().Category != command.Collection {
+		t.Errorf("Error - category. Actual - %s vs Expected - %s", cmd.Spec().Category, command.Collection)
+	}
+}
@@ -0,0 +1,69 @@
+package stats
+
+// Nature raises one stat by 10% and lowers another by 10%. Natures that
+// would raise and lower the same stat, and the zero Nature, are neutral.
+type Nature struct {
+	Name      string
+	Increased string
+	Decreased string
+}
+
+var Natures = []Nature{
+	{"hardy", "attack", "attack"},
+	{"lonely", "attack", "defense"},
+	{"brave", "attack", "speed"},
+	{"adamant", "attack", "special-attack"},
+	{"naughty", "attack", "special-defense"},
+	{"bold", "defense", "attack"},
+	{"docile", "defense", "defense"},
+	{"relaxed", "defense", "speed"},
+	{"impish", "defense", "special-attack"},
+	{"lax", "defense", "special-defense"},
+	{"timid", "speed", "attack"},
+	{"hasty", "speed", "defense"},
+	{"serious", "speed", "speed"},
+	{"jolly", "speed", "special-attack"},
+	{"naive", "speed", "special-defense"},
+	{"modest", "special-attack", "attack"},
+	{"mild", "special-attack", "defense"},
+	{"quiet", "special-attack", "speed"},
+	{"bashful", "special-attack", "special-attack"},
+	{"rash", "special-attack", "special-defense"},
+	{"calm", "special-defense", "attack"},
+	{"gentle", "special-defense", "defense"},
+	{"sassy", "special-defense", "speed"},
+	{"careful", "special-defense", "special-attack"},
+	{"quirky", "special-defense", "special-defense"},
+}
+
+// NatureByName looks up a nature, returning the neutral zero Nature if the
+// name isn't one.
+func NatureByName(name string) (Nature, bool) {
+	for _, n := range Natures {
+		if n.Name == name {
+			return n, true
+		}
+	}
+	return Nature{}, false
+}
+
+func RandomNature(rng RNG) Nature {
+	return Natures[rng.Intn(len(Natures))]
+}
+
+func (n Nature) Neutral() bool {
+	return n.Increased == n.Decreased
+}
+
+// modifier is the nature's effect on stat in tenths.
+func (n Nature) modifier(stat string) int {
+	switch {
+	case n.Neutral():
+		return 10
+	case stat == n.Increased:
+		return 11
+	case stat == n.Decreased:
+		return 9
+	}
+	return 10
+}
@@ -0,0 +1,188 @@
+// Package stats computes the stats of individual pokemon from their base
+// stats, IVs, EVs, nature and level, and converts between experience and
+// level using the species' growth rate.
+package stats
+
+import (
+	"fmt"
+
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+)
+
+const (
+	MaxLevel   = 100
+	MaxIV      = 31
+	MaxEV      = 252
+	MaxTotalEV = 510
+)
+
+// Names are the stats in the order PokeAPI lists them.
+var Names = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+// RNG is the source of randomness for IVs and natures. *rand.Rand satisfies
+// it.
+type RNG interface {
+	Intn(n int) int
+}
+
+// Set holds one value per stat. It's used for base stats, IVs, EVs and the
+// computed stats alike.
+type Set struct {
+	HP        int `json:"hp"`
+	Attack    int `json:"attack"`
+	Defense   int `json:"defense"`
+	SpAttack  int `json:"special-attack"`
+	SpDefense int `json:"special-defense"`
+	Speed     int `json:"speed"`
+}
+
+// Get returns the value of the stat with the PokeAPI name name, or 0 for an
+// unknown name.
+func (s Set) Get(name string) int {
+	if p := s.field(name); p != nil {
+		return *p
+	}
+	return 0
+}
+
+func (s Set) Total() int {
+	return s.HP + s.Attack + s.Defense + s.SpAttack + s.SpDefense + s.Speed
+}
+
+func (s *Set) field(name string) *int {
+	switch name {
+	case "hp":
+		return &s.HP
+	case "attack":
+		return &s.Attack
+	case "defense":
+		return &s.Defense
+	case "special-attack":
+		return &s.SpAttack
+	case "special-defense":
+		return &s.SpDefense
+	case "speed":
+		return &s.Speed
+	}
+	return nil
+}
+
+// Base returns a pokemon's base stats.
+func Base(poke pokeapi.Pokemon) Set {
+	var s Set
+	for _, stat := range poke.Stats {
+		if p := s.field(stat.Stat.Name); p != nil {
+			*p = stat.BaseStat
+		}
+	}
+	return s
+}
+
+// Yield returns the EVs earned for defeating a pokemon.
+func Yield(poke pokeapi.Pokemon) Set {
+	var s Set
+	for _, stat := range poke.Stats {
+		if p := s.field(stat.Stat.Name); p != nil {
+			*p = stat.Effort
+		}
+	}
+	return s
+}
+
+// AddEVs adds yield to evs, stopping at the per-stat and total limits.
+func AddEVs(evs, yield Set) Set {
+	total := evs.Total()
+	for _, name := range Names {
+		gain := min(yield.Get(name), MaxEV-evs.Get(name), MaxTotalEV-total)
+		if gain <= 0 {
+			continue
+		}
+		*evs.field(name) += gain
+		total += gain
+	}
+	return evs
+}
+
+// RandomIVs rolls each IV uniformly from 0 to MaxIV.
+func RandomIVs(rng RNG) Set {
+	var s Set
+	for _, name := range Names {
+		*s.field(name) = rng.Intn(MaxIV + 1)
+	}
+	return s
+}
+
+// Compute returns the actual stats of a pokemon at level.
+func Compute(base, ivs, evs Set, level int, nature Nature) Set {
+	var s Set
+	for _, name := range Names {
+		v := (2*base.Get(name) + ivs.Get(name) + evs.Get(name)/4) * level / 100
+		if name == "hp" {
+			// Shedinja always has exactly 1 HP.
+			if base.HP == 1 {
+				v = 1
+			} else {
+				v += level + 10
+			}
+		} else {
+			v = (v + 5) * nature.modifier(name) / 10
+		}
+		*s.field(name) = v
+	}
+	return s
+}
+
+// Experience returns the total experience needed to reach level for the
+// PokeAPI growth rate rate.
+func Experience(rate string, level int) (int, error) {
+	n := min(max(level, 1), MaxLevel)
+	if n == 1 {
+		return 0, nil
+	}
+	cube := n * n * n
+	switch rate {
+	case "fast":
+		return 4 * cube / 5, nil
+	case "medium":
+		return cube, nil
+	case "medium-slow":
+		return 6*cube/5 - 15*n*n + 100*n - 140, nil
+	case "slow":
+		return 5 * cube / 4, nil
+	case "slow-then-very-fast":
+		switch {
+		case n < 50:
+			return cube * (100 - n) / 50, nil
+		case n < 68:
+			return cube * (150 - n) / 100, nil
+		case n < 98:
+			return cube * ((1911 - 10*n) / 3) / 500, nil
+		}
+		return cube * (160 - n) / 100, nil
+	case "fast-then-very-slow":
+		switch {
+		case n < 15:
+			return cube * ((n+1)/3 + 24) / 50, nil
+		case n < 36:
+			return cube * (n + 14) / 50, nil
+		}
+		return cube * (n/2 + 32) / 50, nil
+	}
+	return 0, fmt.Errorf("unknown growth rate %q", rate)
+}
+
+// Level returns the level a pokemon with exp experience has reached.
+func Level(rate string, exp int) (int, error) {
+	level := 1
+	for level < MaxLevel {
+		next, err := Experience(rate, level+1)
+		if err != nil {
+			return 0, err
+		}
+		if next > exp {
+			break
+		}
+		level++
+	}
+	return level, nil
+}
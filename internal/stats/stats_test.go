@@ -0,0 +1,114 @@
+package stats
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestCompute(t *testing.T) {
+	// Bulbapedia's worked example: a level 78 adamant Garchomp.
+	base := Set{HP: 108, Attack: 130, Defense: 95, SpAttack: 80, SpDefense: 85, Speed: 102}
+	ivs := Set{HP: 24, Attack: 12, Defense: 30, SpAttack: 16, SpDefense: 23, Speed: 5}
+	evs := Set{HP: 74, Attack: 190, Defense: 91, SpAttack: 48, SpDefense: 84, Speed: 23}
+	adamant, _ := NatureByName("adamant")
+	expected := Set{HP: 289, Attack: 278, Defense: 193, SpAttack: 135, SpDefense: 171, Speed: 171}
+	if actual := Compute(base, ivs, evs, 78, adamant); actual != expected {
+		t.Errorf("Error - garchomp stats. Actual - %+v vs Expected - %+v", actual, expected)
+	}
+
+	neutral := Compute(Set{HP: 35, Attack: 55, Defense: 40, SpAttack: 50, SpDefense: 50, Speed: 90}, Set{}, Set{}, 50, Nature{})
+	if neutral.HP != 95 || neutral.Speed != 95 {
+		t.Errorf("Error - neutral stats. Actual - %+v", neutral)
+	}
+
+	shedinja := Compute(Set{HP: 1}, Set{HP: 31}, Set{HP: 252}, 100, Nature{})
+	if shedinja.HP != 1 {
+		t.Errorf("Error - shedinja HP. Actual - %v vs Expected - 1", shedinja.HP)
+	}
+}
+
+func TestExperience(t *testing.T) {
+	cases := []struct {
+		rate     string
+		level    int
+		expected int
+	}{
+		{"medium", 1, 0},
+		{"medium", 10, 1000},
+		{"fast", 100, 800000},
+		{"slow", 100, 1250000},
+		{"medium-slow", 2, 9},
+		{"medium-slow", 100, 1059860},
+		{"slow-then-very-fast", 50, 125000},
+		{"slow-then-very-fast", 100, 600000},
+		{"fast-then-very-slow", 100, 1640000},
+	}
+	for _, c := range cases {
+		actual, err := Experience(c.rate, c.level)
+		if err != nil {
+			t.Fatalf("Error - %s: %v", c.rate, err)
+		}
+		if actual != c.expected {
+			t.Errorf("Error - %s level %d. Actual - %v vs Expected - %v", c.rate, c.level, actual, c.expected)
+		}
+	}
+	if _, err := Experience("sideways", 5); err == nil {
+		t.Errorf("expected an error for an unknown growth rate")
+	}
+}
+
+func TestLevel(t *testing.T) {
+	for _, rate := range []string{"fast", "medium", "medium-slow", "slow", "slow-then-very-fast", "fast-then-very-slow"} {
+		for level := 1; level <= MaxLevel; level++ {
+			exp, _ := Experience(rate, level)
+			actual, err := Level(rate, exp)
+			if err != nil || actual != level {
+				t.Fatalf("Error - %s at %d exp. Actual - %v vs Expected - %v", rate, exp, actual, level)
+			}
+		}
+	}
+	if actual, _ := Level("medium", 999); actual != 9 {
+		t.Errorf("Error - one exp short of level 10. Actual - %v vs Expected - 9", actual)
+	}
+}
+
+func TestAddEVs(t *testing.T) {
+	evs := AddEVs(Set{Attack: 251, Speed: 200}, Set{Attack: 3, Speed: 2})
+	if evs.Attack != MaxEV || evs.Speed != 202 {
+		t.Errorf("Error - per-stat cap. Actual - %+v", evs)
+	}
+	evs = AddEVs(Set{HP: 252, Attack: 252, Defense: 5}, Set{Speed: 3})
+	if evs.Total() != MaxTotalEV || evs.Speed != 1 {
+		t.Errorf("Error - total cap. Actual - %+v", evs)
+	}
+}
+
+func TestRandomIVs(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		ivs := RandomIVs(rng)
+		for _, name := range Names {
+			if v := ivs.Get(name); v < 0 || v > MaxIV {
+				t.Fatalf("Error - %s IV out of range: %v", name, v)
+			}
+		}
+	}
+}
+
+func TestNatures(t *testing.T) {
+	if len(Natures) != 25 {
+		t.Fatalf("Error - nature count. Actual - %v vs Expected - 25", len(Natures))
+	}
+	neutral := 0
+	for _, n := range Natures {
+		if n.Neutral() {
+			neutral++
+		}
+	}
+	if neutral != 5 {
+		t.Errorf("Error - neutral natures. Actual - %v vs Expected - 5", neutral)
+	}
+	if _, ok := NatureByName("bogus"); ok {
+		t.Errorf("expected bogus not to be a nature")
+	}
+}
@@ -0,0 +1,162 @@
+// Package typechart computes type effectiveness from PokeAPI's /type
+// damage relations.
+package typechart
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+)
+
+// Types are the 18 battle types in the order the games list them.
+var Types = []string{
+	"normal", "fire", "water", "electric", "grass", "ice",
+	"fighting", "poison", "ground", "flying", "psychic", "bug",
+	"rock", "ghost", "dragon", "dark", "steel", "fairy",
+}
+
+type Chart struct {
+	generation int
+	types      []string
+	// attack holds the multipliers that aren't 1, keyed by attacking then
+	// defending type.
+	attack map[string]map[string]float64
+}
+
+// New builds the chart for generation gen from the /type resources. A gen
+// of 0 means the current generation.
+func New(types []pokeapi.Type, gen int) *Chart {
+	c := &Chart{
+		generation: gen,
+		attack:     make(map[string]map[string]float64),
+	}
+	exists := make(map[string]bool)
+	for _, t := range types {
+		if gen == 0 || GenerationNumber(t.Generation.Name) <= gen {
+			exists[t.Name] = true
+		}
+	}
+	for _, name := range Types {
+		if exists[name] {
+			c.types = append(c.types, name)
+		}
+	}
+	for _, t := range types {
+		if !exists[t.Name] {
+			continue
+		}
+		rel := relationsIn(t, gen)
+		m := make(map[string]float64)
+		for _, d := range rel.NoDamageTo {
+			m[d.Name] = 0
+		}
+		for _, d := range rel.HalfDamageTo {
+			m[d.Name] = 0.5
+		}
+		for _, d := range rel.DoubleDamageTo {
+			m[d.Name] = 2
+		}
+		for name := range m {
+			if !exists[name] {
+				delete(m, name)
+			}
+		}
+		c.attack[t.Name] = m
+	}
+	return c
+}
+
+// relationsIn returns the damage relations t had in generation gen: those of
+// the earliest past entry that still covers gen, or the current ones.
+func relationsIn(t pokeapi.Type, gen int) pokeapi.TypeRelations {
+	if gen == 0 {
+		return t.DamageRelations
+	}
+	best := 0
+	rel := t.DamageRelations
+	for _, past := range t.PastDamageRelations {
+		g := GenerationNumber(past.Generation.Name)
+		if g >= gen && (best == 0 || g < best) {
+			best = g
+			rel = past.DamageRelations
+		}
+	}
+	return rel
+}
+
+// Types returns the types that exist in the chart's generation.
+func (c *Chart) Types() []string {
+	return c.types
+}
+
+func (c *Chart) Has(t string) bool {
+	_, ok := c.attack[t]
+	return ok
+}
+
+// Multiplier returns the damage multiplier of an attack of type attack
+// against a pokemon with the defending types.
+func (c *Chart) Multiplier(attack string, defenders ...string) float64 {
+	mult := 1.0
+	for _, d := range defenders {
+		if m, ok := c.attack[attack][d]; ok {
+			mult *= m
+		}
+	}
+	return mult
+}
+
+type Matchup struct {
+	Type       string
+	Multiplier float64
+}
+
+// Defense returns the multiplier of every attacking type against a pokemon
+// with the defending types, in chart order.
+func (c *Chart) Defense(defenders ...string) []Matchup {
+	matchups := make([]Matchup, 0, len(c.types))
+	for _, attack := range c.types {
+		matchups = append(matchups, Matchup{Type: attack, Multiplier: c.Multiplier(attack, defenders...)})
+	}
+	return matchups
+}
+
+// PokemonTypes returns the types poke had in generation gen, taking its
+// past_types into account. A gen of 0 means its current types.
+func PokemonTypes(poke pokeapi.Pokemon, gen int) []string {
+	types := []string{}
+	for _, t := range poke.Types {
+		types = append(types, t.Type.Name)
+	}
+	if gen == 0 {
+		return types
+	}
+	best := 0
+	for _, past := range poke.PastTypes {
+		g := GenerationNumber(past.Generation.Name)
+		if g >= gen && (best == 0 || g < best) {
+			best = g
+			types = types[:0:0]
+			for _, t := range past.Types {
+				types = append(types, t.Type.Name)
+			}
+		}
+	}
+	return types
+}
+
+var romanNumerals = map[string]int{
+	"i": 1, "ii": 2, "iii": 3, "iv": 4, "v": 5,
+	"vi": 6, "vii": 7, "viii": 8, "ix": 9, "x": 10,
+}
+
+// GenerationNumber converts "generation-vi", "vi" or "6" to 6. It returns 0
+// for anything else.
+func GenerationNumber(name string) int {
+	name = strings.TrimPrefix(strings.ToLower(name), "generation-")
+	if n, err := strconv.Atoi(name); err == nil && n > 0 {
+		return n
+	}
+	return romanNumerals[name]
+}
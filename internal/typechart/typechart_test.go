@@ -0,0 +1,128 @@
+package typechart
+
+import (
+	"testing"
+
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+)
+
+func refs(names ...string) []pokeapi.NamedAPIResource {
+	out := make([]pokeapi.NamedAPIResource, 0, len(names))
+	for _, n := range names {
+		out = append(out, pokeapi.NamedAPIResource{Name: n})
+	}
+	return out
+}
+
+func ref(name string) pokeapi.NamedAPIResource {
+	return pokeapi.NamedAPIResource{Name: name}
+}
+
+func testTypes() []pokeapi.Type {
+	return []pokeapi.Type{
+		{
+			Name:       "electric",
+			Generation: ref("generation-i"),
+			DamageRelations: pokeapi.TypeRelations{
+				NoDamageTo:     refs("ground"),
+				HalfDamageTo:   refs("electric", "grass", "dragon"),
+				DoubleDamageTo: refs("water", "flying"),
+			},
+		},
+		{
+			Name:       "ghost",
+			Generation: ref("generation-i"),
+			DamageRelations: pokeapi.TypeRelations{
+				NoDamageTo:     refs("normal"),
+				HalfDamageTo:   refs("dark"),
+				DoubleDamageTo: refs("ghost", "psychic"),
+			},
+			PastDamageRelations: []pokeapi.TypeRelationsPast{
+				{
+					Generation: ref("generation-v"),
+					DamageRelations: pokeapi.TypeRelations{
+						NoDamageTo:     refs("normal"),
+						HalfDamageTo:   refs("dark", "steel"),
+						DoubleDamageTo: refs("ghost", "psychic"),
+					},
+				},
+			},
+		},
+		{Name: "water", Generation: ref("generation-i")},
+		{Name: "flying", Generation: ref("generation-i")},
+		{Name: "ground", Generation: ref("generation-i")},
+		{Name: "steel", Generation: ref("generation-ii")},
+		{Name: "dark", Generation: ref("generation-ii")},
+		{Name: "fairy", Generation: ref("generation-vi")},
+	}
+}
+
+func TestMultiplier(t *testing.T) {
+	chart := New(testTypes(), 0)
+	cases := []struct {
+		attack   string
+		defend   []string
+		expected float64
+	}{
+		{attack: "electric", defend: []string{"water"}, expected: 2},
+		{attack: "electric", defend: []string{"water", "flying"}, expected: 4},
+		{attack: "electric", defend: []string{"water", "ground"}, expected: 0},
+		{attack: "electric", defend: []string{"steel"}, expected: 1},
+		{attack: "ghost", defend: []string{"steel"}, expected: 1},
+	}
+	for _, c := range cases {
+		actual := chart.Multiplier(c.attack, c.defend...)
+		if actual != c.expected {
+			t.Errorf("%s vs %v: Actual - %v vs Expected - %v", c.attack, c.defend, actual, c.expected)
+		}
+	}
+}
+
+func TestGenerationAware(t *testing.T) {
+	gen5 := New(testTypes(), 5)
+	if m := gen5.Multiplier("ghost", "steel"); m != 0.5 {
+		t.Errorf("ghost vs steel in gen 5: Actual - %v vs Expected - 0.5", m)
+	}
+	gen1 := New(testTypes(), 1)
+	if gen1.Has("steel") || gen1.Has("dark") {
+		t.Errorf("expected no steel or dark types in gen 1, got %v", gen1.Types())
+	}
+	if New(testTypes(), 5).Has("fairy") {
+		t.Errorf("expected no fairy type before gen 6")
+	}
+}
+
+func TestPokemonTypes(t *testing.T) {
+	var poke pokeapi.Pokemon
+	poke.Types = []pokeapi.PokemonType{
+		{Slot: 1, Type: ref("normal")},
+		{Slot: 2, Type: ref("fairy")},
+	}
+	poke.PastTypes = []pokeapi.PokemonTypePast{
+		{Generation: ref("generation-v"), Types: poke.Types[:1]},
+	}
+
+	if types := PokemonTypes(poke, 0); len(types) != 2 {
+		t.Errorf("expected current types, got %v", types)
+	}
+	if types := PokemonTypes(poke, 6); len(types) != 2 {
+		t.Errorf("expected current types in gen 6, got %v", types)
+	}
+	if types := PokemonTypes(poke, 3); len(types) != 1 || types[0] != "normal" {
+		t.Errorf("expected [normal] in gen 3, got %v", types)
+	}
+}
+
+func TestGenerationNumber(t *testing.T) {
+	cases := map[string]int{
+		"generation-vi": 6,
+		"iv":            4,
+		"8":             8,
+		"latest":        0,
+	}
+	for input, expected := range cases {
+		if actual := GenerationNumber(input); actual != expected {
+			t.Errorf("%s: Actual - %d vs Expected - %d", input, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Package wild picks random wild pokemon for a location area.
+package wild
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+)
+
+type RNG interface {
+	Intn(n int) int
+}
+
+type Pokemon struct {
+	Name  string `json:"name"`
+	Level int    `json:"level"`
+	// HP and MaxHP are set once the pokemon has been attacked; until then
+	// it is at full health. Status is the ailment it's suffering from.
+	HP     int    `json:"hp,omitempty"`
+	MaxHP  int    `json:"max_hp,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+// Methods returns the encounter methods that can find pokemon in loc in
+// version.
+func Methods(loc pokeapi.LocationArea, version string) []string {
+	methods := []string{}
+	seen := make(map[string]bool)
+	for _, pe := range loc.PokemonEncounters {
+		for _, vd := range pe.VersionDetails {
+			if vd.Version.Name != version {
+				continue
+			}
+			for _, enc := range vd.EncounterDetails {
+				if !seen[enc.Method.Name] {
+					seen[enc.Method.Name] = true
+					methods = append(methods, enc.Method.Name)
+				}
+			}
+		}
+	}
+	return methods
+}
+
+// Rate returns the percentage chance that searching with method finds any
+// pokemon at all. Areas that don't report a rate for a method always find one.
+func Rate(loc pokeapi.LocationArea, version, method string) int {
+	for _, emr := range loc.EncounterMethodRates {
+		if emr.EncounterMethod.Name != method {
+			continue
+		}
+		for _, vd := range emr.VersionDetails {
+			if vd.Version.Name == version && vd.Rate > 0 {
+				return min(vd.Rate, 100)
+			}
+		}
+	}
+	return 100
+}
+
+// Encounter searches loc with method. It first rolls against the method's
+// encounter rate, returning false if nothing appeared, then picks a slot
+// weighted by its chance and a level within the slot's range.
+func Encounter(rng RNG, loc pokeapi.LocationArea, version, method string) (Pokemon, bool, error) {
+	type slot struct {
+		name string
+		enc  pokeapi.Encounter
+	}
+	slots := []slot{}
+	total := 0
+	for _, pe := range loc.PokemonEncounters {
+		for _, vd := range pe.VersionDetails {
+			if vd.Version.Name != version {
+				continue
+			}
+			for _, enc := range vd.EncounterDetails {
+				if enc.Method.Name == method && enc.Chance > 0 {
+					slots = append(slots, slot{name: pe.Pokemon.Name, enc: enc})
+					total += enc.Chance
+				}
+			}
+		}
+	}
+	if len(slots) == 0 {
+		methods := Methods(loc, version)
+		if len(methods) == 0 {
+			return Pokemon{}, false, fmt.Errorf("No pokemon can be found in %s in %s.", loc.Name, version)
+		}
+		return Pokemon{}, false, fmt.Errorf("No pokemon can be found by %s here. Try: %s", method, strings.Join(methods, ", "))
+	}
+	if rng.Intn(100) >= Rate(loc, version, method) {
+		return Pokemon{}, false, nil
+	}
+	r := rng.Intn(total)
+	for _, s := range slots {
+		if r < s.enc.Chance {
+			minLevel := max(s.enc.MinLevel, 1)
+			maxLevel := max(s.enc.MaxLevel, minLevel)
+			level := minLevel + rng.Intn(maxLevel-minLevel+1)
+			return Pokemon{Name: s.name, Level: level}, true, nil
+		}
+		r -= s.enc.Chance
+	}
+	return Pokemon{}, false, nil
+}
@@ -0,0 +1,98 @@
+package wild
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+)
+
+func testArea() pokeapi.LocationArea {
+	walk := pokeapi.NamedAPIResource{Name: "walk"}
+	red := pokeapi.NamedAPIResource{Name: "red"}
+	loc := pokeapi.LocationArea{
+		Name: "viridian-forest-area",
+		PokemonEncounters: []pokeapi.PokemonEncounter{
+			{
+				Pokemon: pokeapi.NamedAPIResource{Name: "caterpie"},
+				VersionDetails: []pokeapi.VersionEncounterDetail{
+					{
+						Version: red,
+						EncounterDetails: []pokeapi.Encounter{
+							{MinLevel: 3, MaxLevel: 5, Chance: 75, Method: walk},
+						},
+					},
+				},
+			},
+			{
+				Pokemon: pokeapi.NamedAPIResource{Name: "pikachu"},
+				VersionDetails: []pokeapi.VersionEncounterDetail{
+					{
+						Version: red,
+						EncounterDetails: []pokeapi.Encounter{
+							{MinLevel: 3, MaxLevel: 3, Chance: 25, Method: walk},
+						},
+					},
+				},
+			},
+		},
+	}
+	return loc
+}
+
+func TestEncounterDistribution(t *testing.T) {
+	loc := testArea()
+	rng := rand.New(rand.NewSource(1))
+	counts := make(map[string]int)
+	const trials = 10000
+	for i := 0; i < trials; i++ {
+		poke, ok, err := Encounter(rng, loc, "red", "walk")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !ok {
+			t.Fatalf("expected an encounter without a reported rate")
+		}
+		if poke.Level < 3 || poke.Level > 5 {
+			t.Errorf("level %d out of range", poke.Level)
+		}
+		counts[poke.Name]++
+	}
+	pikachu := float64(counts["pikachu"]) / trials
+	if pikachu < 0.22 || pikachu > 0.28 {
+		t.Errorf("pikachu rate: Actual - %.3f vs Expected - 0.25", pikachu)
+	}
+}
+
+func TestEncounterRate(t *testing.T) {
+	loc := testArea()
+	var rate pokeapi.EncounterMethodRate
+	rate.EncounterMethod.Name = "walk"
+	rate.VersionDetails = []pokeapi.EncounterVersionDetails{
+		{Rate: 10, Version: pokeapi.NamedAPIResource{Name: "red"}},
+	}
+	loc.EncounterMethodRates = append(loc.EncounterMethodRates, rate)
+	if Rate(loc, "red", "walk") != 10 {
+		t.Fatalf("expected a rate of 10, got %d", Rate(loc, "red", "walk"))
+	}
+	rng := rand.New(rand.NewSource(1))
+	found := 0
+	const trials = 10000
+	for i := 0; i < trials; i++ {
+		_, ok, _ := Encounter(rng, loc, "red", "walk")
+		if ok {
+			found++
+		}
+	}
+	actual := float64(found) / trials
+	if actual < 0.08 || actual > 0.12 {
+		t.Errorf("encounter rate: Actual - %.3f vs Expected - 0.10", actual)
+	}
+}
+
+func TestEncounterUnknownMethod(t *testing.T) {
+	_, _, err := Encounter(rand.New(rand.NewSource(1)), testArea(), "red", "surf")
+	if err == nil {
+		t.Errorf("expected an error for a method with no pokemon")
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/session"
+	"github.com/smwalke83/pokedex/internal/stats"
+)
+
+// Friendship a pokemon gains for winning a battle and for each level it
+// grows.
+const (
+	winFriendship     = 2
+	levelUpFriendship = 5
+)
+
+// gainExperience rewards the owned pokemon id for defeating a pokemon at
+// level with experience, EVs and friendship, and levels it up if it has
+// earned enough.
+func gainExperience(ctx context.Context, c *session.Session, id int, defeated pokeapi.Pokemon, level int) error {
+	caught, ok := c.Owned[id]
+	if !ok {
+		return fmt.Errorf("You don't have a Pokemon with ID %d.", id)
+	}
+	name := caught.DisplayName()
+	if caught.GrowthRate == "" {
+		species, err := c.Client.GetPokemonSpecies(ctx, caught.SpeciesName())
+		if err != nil {
+			return err
+		}
+		caught.GrowthRate = species.GrowthRate.Name
+	}
+	floor, err := stats.Experience(caught.GrowthRate, caught.CurrentLevel())
+	if err != nil {
+		return err
+	}
+	// Older saves have a level but no experience to go with it.
+	caught.Experience = max(caught.Experience, floor)
+
+	gained := defeated.BaseExperience * level / 7
+	caught.Experience += gained
+	caught.EVs = stats.AddEVs(caught.EVs, stats.Yield(defeated))
+	fmt.Printf("%s gained %d experience.\n", name, gained)
+	newLevel, err := stats.Level(caught.GrowthRate, caught.Experience)
+	if err != nil {
+		return err
+	}
+	caught.Befriend(winFriendship)
+	if newLevel > caught.CurrentLevel() {
+		fmt.Printf("%s grew to level %d!\n", name, newLevel)
+		caught.Befriend((newLevel - caught.CurrentLevel()) * levelUpFriendship)
+	}
+	caught.Level = max(newLevel, caught.CurrentLevel())
+	c.Owned[id] = caught
+	return nil
+}
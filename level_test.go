@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/session"
+)
+
+func TestGainExperience(t *testing.T) {
+	c := &session.Session{Owned: map[int]session.CaughtPokemon{
+		1: {ID: 1, Pokemon: pokeapi.Pokemon{Name: "pikachu"}, Level: 5, Experience: 125, GrowthRate: "medium"},
+	}}
+	defeated := pokeapi.Pokemon{
+		Name:           "rattata",
+		BaseExperience: 51,
+		Stats:          []pokeapi.PokemonStat{{BaseStat: 72, Effort: 1, Stat: pokeapi.NamedAPIResource{Name: "speed"}}},
+	}
+	if err := gainExperience(context.Background(), c, 1, defeated, 20); err != nil {
+		t.Fatal(err)
+	}
+	actual := c.Owned[1]
+	// 51*20/7 = 145 experience takes pikachu from 125 to 270, past level 6
+	// at 216. Winning and growing a level are both worth friendship.
+	if actual.Experience != 270 || actual.Level != 6 || actual.EVs.Speed != 1 || actual.Friendship != 7 {
+		t.Errorf("Error - after battle. Actual - %+v", actual)
+	}
+}
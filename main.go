@@ -1,12 +1,111 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/smwalke83/pokedex/internal/pokeapi"
 	"github.com/smwalke83/pokedex/internal/pokecache"
+	"github.com/smwalke83/pokedex/internal/session"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"strings"
 	"time"
 )
 
 func main() {
+	baseURL := flag.String("base-url", pokeapi.DefaultBaseURL, "PokeAPI base URL, e.g. a local mirror")
+	timeout := flag.Duration("timeout", 30*time.Second, "maximum time a single command may take (0 disables)")
+	cacheDir := flag.String("cache-dir", "", "directory for a persistent response cache (disabled when empty)")
+	cacheTTL := flag.Duration("cache-ttl", 7*24*time.Hour, "how long responses stay in the persistent cache (0 keeps them until evicted)")
+	cacheMaxBytes := flag.Int64("cache-max-bytes", 64<<20, "size limit of the persistent cache in bytes (0 is unlimited)")
+	savePath := flag.String("save", session.DefaultSavePath(), "save file used by save, load and autosave")
+	historyPath := flag.String("history", session.DefaultHistoryPath(), "file the interactive command history is kept in (in memory only when empty)")
+	autosave := flag.Bool("autosave", true, "load the save file on start and save it on exit")
+	command := flag.String("c", "", "run the given commands, separated by semicolons, then exit")
+	script := flag.String("f", "", "run the commands in the given script file, then exit")
+	retries := flag.Int("retries", pokeapi.DefaultRetryPolicy.MaxAttempts, "how many times to try a request that fails with a network error, 429 or 5xx")
+	retryDeadline := flag.Duration("retry-deadline", pokeapi.DefaultRetryPolicy.Deadline, "longest time to spend on one request across its retries (0 leaves it to -timeout)")
+	debug := flag.Bool("debug", false, "log every API request attempt to stderr")
+	seed := flag.Int64("seed", 0, "seed for catching, encounters, battles and IVs, to make a session reproducible (random when unset)")
+	flag.Parse()
+	var in io.Reader = os.Stdin
+	interactive := isTerminal(os.Stdin)
+	if *command != "" {
+		in = strings.NewReader(*command)
+		interactive = false
+	} else if *script != "" {
+		f, err := os.Open(*script)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error opening script: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		in = f
+		interactive = false
+	}
+	// Scripts neither read nor overwrite the save file unless asked to.
+	if !interactive && !flagWasSet("autosave") {
+		*autosave = false
+	}
 	interval := 5 * time.Second
-	cache := pokecache.NewCache(interval)
-	startRepl(cache)
-}
\ No newline at end of file
+	var cache *pokecache.Cache
+	if *cacheDir == "" {
+		cache = pokecache.NewCache(interval)
+	} else {
+		var err error
+		cache, err = pokecache.NewCacheWithDisk(interval, pokecache.DiskOptions{
+			Dir:      *cacheDir,
+			TTL:      *cacheTTL,
+			MaxBytes: *cacheMaxBytes,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error opening cache directory: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	client := pokeapi.NewClient(*baseURL, nil, cache)
+	client.Retry.MaxAttempts = *retries
+	client.Retry.Deadline = *retryDeadline
+	if *debug {
+		client.Logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+	c := session.New(client)
+	c.Timeout = *timeout
+	c.SavePath = *savePath
+	c.Autosave = *autosave
+	c.HistoryPath = *historyPath
+	if flagWasSet("seed") {
+		c.Reseed(*seed)
+	}
+	if *autosave {
+		err := c.Load(*savePath)
+		if err == nil {
+			fmt.Printf("Loaded %d pokemon from %s\n", len(c.Owned), *savePath)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "Error loading save file: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	os.Exit(startRepl(c, in, interactive))
+}
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+func flagWasSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
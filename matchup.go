@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/smwalke83/pokedex/internal/session"
+	"github.com/smwalke83/pokedex/internal/typechart"
+)
+
+func parseGeneration(flags map[string]string) (int, error) {
+	if flags["gen"] == "" {
+		return 0, nil
+	}
+	gen := typechart.GenerationNumber(flags["gen"])
+	if gen == 0 {
+		return 0, fmt.Errorf("Invalid command - %q is not a generation.", flags["gen"])
+	}
+	return gen, nil
+}
+
+func effectiveness(mult float64) string {
+	switch {
+	case mult == 0:
+		return "no effect"
+	case mult < 1:
+		return "not very effective"
+	case mult > 1:
+		return "super effective"
+	}
+	return "normal damage"
+}
+
+func commandMatchup(ctx context.Context, c *session.Session, args []string, flags map[string]string) error {
+	gen, err := parseGeneration(flags)
+	if err != nil {
+		return err
+	}
+	attackerName, err := c.ResolveName(ctx, "pokemon", args[0])
+	if err != nil {
+		return err
+	}
+	defenderName, err := c.ResolveName(ctx, "pokemon", args[1])
+	if err != nil {
+		return err
+	}
+	attacker, err := c.Client.GetPokemon(ctx, attackerName)
+	if err != nil {
+		return err
+	}
+	defender, err := c.Client.GetPokemon(ctx, defenderName)
+	if err != nil {
+		return err
+	}
+	chart, err := c.TypeChart(ctx, gen)
+	if err != nil {
+		return err
+	}
+	attackTypes := typechart.PokemonTypes(attacker, gen)
+	defendTypes := typechart.PokemonTypes(defender, gen)
+	fmt.Printf("%s (%s) vs %s (%s):\n", attacker.Name, strings.Join(attackTypes, ", "), defender.Name, strings.Join(defendTypes, ", "))
+	for _, t := range attackTypes {
+		mult := chart.Multiplier(t, defendTypes...)
+		fmt.Printf("  %s: %gx (%s)\n", t, mult, effectiveness(mult))
+	}
+	return nil
+}
+
+func commandWeakness(ctx context.Context, c *session.Session, args []string, flags map[string]string) error {
+	gen, err := parseGeneration(flags)
+	if err != nil {
+		return err
+	}
+	name, err := c.ResolveName(ctx, "pokemon", args[0])
+	if err != nil {
+		return err
+	}
+	poke, err := c.Client.GetPokemon(ctx, name)
+	if err != nil {
+		return err
+	}
+	chart, err := c.TypeChart(ctx, gen)
+	if err != nil {
+		return err
+	}
+	types := typechart.PokemonTypes(poke, gen)
+	byMultiplier := make(map[float64][]string)
+	for _, m := range chart.Defense(types...) {
+		byMultiplier[m.Multiplier] = append(byMultiplier[m.Multiplier], m.Type)
+	}
+	fmt.Printf("%s (%s) takes:\n", poke.Name, strings.Join(types, ", "))
+	for _, mult := range []float64{4, 2, 0.5, 0.25, 0} {
+		if len(byMultiplier[mult]) > 0 {
+			fmt.Printf("  %gx: %s\n", mult, strings.Join(byMultiplier[mult], ", "))
+		}
+	}
+	return nil
+}
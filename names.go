@@ -0,0 +1,19 @@
+package main
+
+import (
+	"github.com/smwalke83/pokedex/internal/command"
+	"github.com/smwalke83/pokedex/internal/fuzzy"
+)
+
+// lookupCommand finds a command by name, alias or unique prefix.
+func lookupCommand(name string) (command.Command, error) {
+	if cmd, ok := command.Default.Lookup(name); ok {
+		return cmd, nil
+	}
+	name, err := fuzzy.NewIndex("command", commandNames(nil)).Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	cmd, _ := command.Default.Lookup(name)
+	return cmd, nil
+}
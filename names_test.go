@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+func TestLookupCommand(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "catch", expected: "catch"},
+		{input: "dex", expected: "pokedex"},
+		{input: "insp", expected: "inspect"},
+		{input: "weak", expected: "weakness"},
+	}
+	for _, c := range cases {
+		cmd, err := lookupCommand(c.input)
+		if err != nil || cmd.Spec().Name != c.expected {
+			t.Errorf("Error - Lookup %q: Actual - %v, %v vs Expected - %s", c.input, cmd, err, c.expected)
+		}
+	}
+	for input, expected := range map[string]string{
+		"hlep": `Unknown command "hlep" - did you mean help?`,
+		"ma":   `"ma" could be more than one command: map, mapb or matchup.`,
+	} {
+		if _, err := lookupCommand(input); err == nil || err.Error() != expected {
+			t.Errorf("Error - Lookup %q: Actual - %v vs Expected - %s", input, err, expected)
+		}
+	}
+}
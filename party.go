@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/session"
+	"github.com/smwalke83/pokedex/internal/stats"
+	"github.com/smwalke83/pokedex/internal/typechart"
+)
+
+type typeCoverage struct {
+	Type   string
+	Weak   int
+	Resist int
+	Immune int
+}
+
+type partyReport struct {
+	// Defense counts, for every attacking type, how many members are weak
+	// to, resist or are immune to it.
+	Defense          []typeCoverage
+	SharedWeaknesses []typeCoverage
+	// The best multiplier any member's own types get against each
+	// single-typed defender, grouped by how effective it is.
+	SuperEffective []string
+	Neutral        []string
+	Resisted       []string
+	StatTotals     map[string]int
+}
+
+func commandParty(ctx context.Context, c *session.Session, args []string, flags map[string]string) error {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+	switch args[0] {
+	case "list":
+		return partyList(c)
+	case "add":
+		if len(args) < 2 {
+			return errors.New("Please enter the ID of the Pokemon to add to your party")
+		}
+		return partyAdd(c, args[1])
+	case "remove":
+		if len(args) < 2 {
+			return errors.New("Please enter the ID of the Pokemon to remove from your party")
+		}
+		return partyRemove(c, args[1])
+	case "analyze":
+		gen, err := parseGeneration(flags)
+		if err != nil {
+			return err
+		}
+		return partyAnalyze(ctx, c, gen)
+	}
+	return fmt.Errorf("Invalid command - unknown party action %q, use add, remove, list or analyze.", args[0])
+}
+
+func partyList(c *session.Session) error {
+	fmt.Printf("Your Party (%d/%d):\n", len(c.Party), session.MaxPartySize)
+	if len(c.Party) == 0 {
+		fmt.Println("Your party is empty - add pokemon with party add <id>.")
+	}
+	for _, id := range c.Party {
+		caught := c.Owned[id]
+		fmt.Printf(" - %s Lv. %d\n", caught.Label(), caught.CurrentLevel())
+	}
+	return nil
+}
+
+func partyAdd(c *session.Session, ref string) error {
+	caught, err := c.FindOwned(ref)
+	if err != nil {
+		return err
+	}
+	if c.InParty(caught.ID) {
+		return fmt.Errorf("%s is already in your party.", caught.Label())
+	}
+	if len(c.Party) >= session.MaxPartySize {
+		return fmt.Errorf("Your party is full (%d Pokemon) - remove one first.", session.MaxPartySize)
+	}
+	c.Party = append(c.Party, caught.ID)
+	fmt.Printf("%s joined your party.\n", caught.Label())
+	return nil
+}
+
+func partyRemove(c *session.Session, ref string) error {
+	caught, err := c.FindOwned(ref)
+	if err != nil {
+		return err
+	}
+	for i, member := range c.Party {
+		if member == caught.ID {
+			c.Party = append(c.Party[:i], c.Party[i+1:]...)
+			fmt.Printf("%s left your party and was sent to the box.\n", caught.Label())
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is not in your party.", caught.Label())
+}
+
+func partyAnalyze(ctx context.Context, c *session.Session, gen int) error {
+	if len(c.Party) == 0 {
+		return errors.New("Your party is empty - add pokemon with party add <id>.")
+	}
+	chart, err := c.TypeChart(ctx, gen)
+	if err != nil {
+		return err
+	}
+	members := make([]pokeapi.Pokemon, 0, len(c.Party))
+	for _, id := range c.Party {
+		members = append(members, c.Owned[id].Pokemon)
+	}
+	report := analyzeParty(chart, members, gen)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Defensive coverage:")
+	fmt.Fprintln(w, "  TYPE\tWEAK\tRESIST\tIMMUNE")
+	for _, tc := range report.Defense {
+		fmt.Fprintf(w, "  %s\t%d\t%d\t%d\n", tc.Type, tc.Weak, tc.Resist, tc.Immune)
+	}
+	w.Flush()
+	if len(report.SharedWeaknesses) == 0 {
+		fmt.Println("Shared weaknesses: none")
+	} else {
+		shared := make([]string, 0, len(report.SharedWeaknesses))
+		for _, tc := range report.SharedWeaknesses {
+			shared = append(shared, fmt.Sprintf("%s (%d)", tc.Type, tc.Weak))
+		}
+		fmt.Printf("Shared weaknesses: %s\n", strings.Join(shared, ", "))
+	}
+	fmt.Println("Offensive STAB coverage:")
+	fmt.Printf("  Super effective against: %s\n", listOrNone(report.SuperEffective))
+	fmt.Printf("  Neutral at best against: %s\n", listOrNone(report.Neutral))
+	fmt.Printf("  Resisted by: %s\n", listOrNone(report.Resisted))
+
+	fmt.Fprintln(w, "Base stats:")
+	fmt.Fprintln(w, "  NAME\tHP\tATK\tDEF\tSPA\tSPD\tSPE\tTOTAL")
+	for _, poke := range members {
+		fmt.Fprintf(w, "  %s", poke.Name)
+		total := 0
+		base := stats.Base(poke)
+		for _, stat := range stats.Names {
+			fmt.Fprintf(w, "\t%d", base.Get(stat))
+			total += base.Get(stat)
+		}
+		fmt.Fprintf(w, "\t%d\n", total)
+	}
+	fmt.Fprint(w, "  party total")
+	total := 0
+	for _, stat := range stats.Names {
+		fmt.Fprintf(w, "\t%d", report.StatTotals[stat])
+		total += report.StatTotals[stat]
+	}
+	fmt.Fprintf(w, "\t%d\n", total)
+	return w.Flush()
+}
+
+func listOrNone(list []string) string {
+	if len(list) == 0 {
+		return "none"
+	}
+	return strings.Join(list, ", ")
+}
+
+func analyzeParty(chart *typechart.Chart, members []pokeapi.Pokemon, gen int) partyReport {
+	report := partyReport{
+		StatTotals: make(map[string]int),
+	}
+	memberTypes := make([][]string, 0, len(members))
+	for _, poke := range members {
+		memberTypes = append(memberTypes, typechart.PokemonTypes(poke, gen))
+		base := stats.Base(poke)
+		for _, stat := range stats.Names {
+			report.StatTotals[stat] += base.Get(stat)
+		}
+	}
+	for _, attack := range chart.Types() {
+		tc := typeCoverage{Type: attack}
+		for _, types := range memberTypes {
+			mult := chart.Multiplier(attack, types...)
+			switch {
+			case mult == 0:
+				tc.Immune++
+			case mult < 1:
+				tc.Resist++
+			case mult > 1:
+				tc.Weak++
+			}
+		}
+		report.Defense = append(report.Defense, tc)
+		if tc.Weak >= 2 {
+			report.SharedWeaknesses = append(report.SharedWeaknesses, tc)
+		}
+	}
+	sort.SliceStable(report.SharedWeaknesses, func(i, j int) bool {
+		return report.SharedWeaknesses[i].Weak > report.SharedWeaknesses[j].Weak
+	})
+	for _, defend := range chart.Types() {
+		best := 0.0
+		for _, types := range memberTypes {
+			for _, attack := range types {
+				best = max(best, chart.Multiplier(attack, defend))
+			}
+		}
+		switch {
+		case best > 1:
+			report.SuperEffective = append(report.SuperEffective, defend)
+		case best == 1:
+			report.Neutral = append(report.Neutral, defend)
+		default:
+			report.Resisted = append(report.Resisted, defend)
+		}
+	}
+	return report
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/stats"
+	"github.com/smwalke83/pokedex/internal/typechart"
+)
+
+func named(names ...string) []pokeapi.NamedAPIResource {
+	out := []pokeapi.NamedAPIResource{}
+	for _, n := range names {
+		out = append(out, pokeapi.NamedAPIResource{Name: n})
+	}
+	return out
+}
+
+func testPokemon(name string, types []string, baseStats ...int) pokeapi.Pokemon {
+	poke := pokeapi.Pokemon{Name: name}
+	for i, t := range types {
+		poke.Types = append(poke.Types, pokeapi.PokemonType{Slot: i + 1, Type: pokeapi.NamedAPIResource{Name: t}})
+	}
+	for i, base := range baseStats {
+		poke.Stats = append(poke.Stats, pokeapi.PokemonStat{BaseStat: base, Stat: pokeapi.NamedAPIResource{Name: stats.Names[i]}})
+	}
+	return poke
+}
+
+func TestAnalyzeParty(t *testing.T) {
+	chart := typechart.New([]pokeapi.Type{
+		{Name: "electric", DamageRelations: pokeapi.TypeRelations{
+			NoDamageTo:     named("ground"),
+			HalfDamageTo:   named("electric"),
+			DoubleDamageTo: named("water", "flying"),
+		}},
+		{Name: "water", DamageRelations: pokeapi.TypeRelations{
+			HalfDamageTo:   named("water"),
+			DoubleDamageTo: named("ground"),
+		}},
+		{Name: "ground", DamageRelations: pokeapi.TypeRelations{
+			NoDamageTo:     named("flying"),
+			DoubleDamageTo: named("electric"),
+		}},
+		{Name: "flying"},
+	}, 0)
+	members := []pokeapi.Pokemon{
+		testPokemon("pikachu", []string{"electric"}, 35, 55, 40, 50, 50, 90),
+		testPokemon("gyarados", []string{"water", "flying"}, 95, 125, 79, 60, 100, 81),
+		testPokemon("wingull", []string{"water", "flying"}, 40, 30, 30, 55, 30, 85),
+	}
+	report := analyzeParty(chart, members, 0)
+
+	if len(report.SharedWeaknesses) != 1 || report.SharedWeaknesses[0].Type != "electric" || report.SharedWeaknesses[0].Weak != 2 {
+		t.Errorf("Error - Unexpected shared weaknesses: %+v", report.SharedWeaknesses)
+	}
+	for _, tc := range report.Defense {
+		if tc.Type == "ground" && (tc.Weak != 1 || tc.Immune != 2) {
+			t.Errorf("Error - Unexpected ground coverage: %+v", tc)
+		}
+	}
+	if strings.Join(report.SuperEffective, ",") != "water,ground,flying" {
+		t.Errorf("Error - Unexpected super effective coverage: %v", report.SuperEffective)
+	}
+	if strings.Join(report.Neutral, ",") != "electric" || len(report.Resisted) != 0 {
+		t.Errorf("Error - Unexpected neutral/resisted coverage: %v %v", report.Neutral, report.Resisted)
+	}
+	if report.StatTotals["hp"] != 170 || report.StatTotals["speed"] != 256 {
+		t.Errorf("Error - Unexpected stat totals: %v", report.StatTotals)
+	}
+}
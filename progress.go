@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/smwalke83/pokedex/internal/session"
+)
+
+type dexProgress struct {
+	Seen   int
+	Caught int
+	Total  int
+}
+
+// countProgress counts how many of species have been seen and caught.
+func countProgress(c *session.Session, species []string) dexProgress {
+	p := dexProgress{Total: len(species)}
+	for _, name := range species {
+		entry := c.Pokedex[name]
+		if entry.Seen {
+			p.Seen++
+		}
+		if entry.Caught {
+			p.Caught++
+		}
+	}
+	return p
+}
+
+func (p dexProgress) String() string {
+	return fmt.Sprintf("seen %d/%d (%.1f%%), caught %d/%d (%.1f%%)", p.Seen, p.Total, percent(p.Seen, p.Total), p.Caught, p.Total, percent(p.Caught, p.Total))
+}
+
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) * 100 / float64(total)
+}
+
+func commandProgress(ctx context.Context, c *session.Session, args []string, _ map[string]string) error {
+	if len(args) > 0 {
+		return pokedexProgress(ctx, c, args[0])
+	}
+	national, err := c.Client.GetPokedex(ctx, "national")
+	if err != nil {
+		return err
+	}
+	species := make([]string, 0, len(national.PokemonEntries))
+	for _, entry := range national.PokemonEntries {
+		species = append(species, entry.PokemonSpecies.Name)
+	}
+	fmt.Printf("National Pokedex: %s\n", countProgress(c, species))
+
+	gens, err := c.Client.ListGenerations(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  GENERATION\tREGION\tSEEN\tCAUGHT\tTOTAL\tCOMPLETE")
+	for _, res := range gens.Results {
+		gen, err := c.Client.GetGeneration(ctx, res.Name)
+		if err != nil {
+			return err
+		}
+		species := make([]string, 0, len(gen.PokemonSpecies))
+		for _, s := range gen.PokemonSpecies {
+			species = append(species, s.Name)
+		}
+		p := countProgress(c, species)
+		fmt.Fprintf(w, "  %s\t%s\t%d\t%d\t%d\t%.1f%%\n", gen.Name, gen.MainRegion.Name, p.Seen, p.Caught, p.Total, percent(p.Caught, p.Total))
+	}
+	return w.Flush()
+}
+
+// pokedexProgress reports progress through one regional pokedex and lists
+// the pokemon still to catch.
+func pokedexProgress(ctx context.Context, c *session.Session, name string) error {
+	dex, err := c.Client.GetPokedex(ctx, name)
+	if err != nil {
+		return err
+	}
+	species := make([]string, 0, len(dex.PokemonEntries))
+	for _, entry := range dex.PokemonEntries {
+		species = append(species, entry.PokemonSpecies.Name)
+	}
+	p := countProgress(c, species)
+	fmt.Printf("%s Pokedex: %s\n", dex.Name, p)
+	if p.Caught == p.Total {
+		fmt.Printf("You've caught every Pokemon in the %s Pokedex!\n", dex.Name)
+		return nil
+	}
+	fmt.Println("Still to catch:")
+	for _, entry := range dex.PokemonEntries {
+		seen := c.Pokedex[entry.PokemonSpecies.Name]
+		switch {
+		case seen.Caught:
+			continue
+		case seen.Seen:
+			fmt.Printf("  #%03d %s (seen)\n", entry.EntryNumber, entry.PokemonSpecies.Name)
+		default:
+			fmt.Printf("  #%03d %s\n", entry.EntryNumber, entry.PokemonSpecies.Name)
+		}
+	}
+	return nil
+}
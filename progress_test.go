@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/session"
+)
+
+func TestCountProgress(t *testing.T) {
+	c := &session.Session{}
+	c.MarkSeen("rattata")
+	c.MarkCaught("pikachu")
+	// Seeing a caught pokemon again doesn't forget the catch.
+	c.MarkSeen("pikachu")
+	c.MarkSeen("mewtwo")
+
+	actual := countProgress(c, []string{"bulbasaur", "pikachu", "rattata", "caterpie"})
+	expected := dexProgress{Seen: 2, Caught: 1, Total: 4}
+	if actual != expected {
+		t.Errorf("Error - progress. Actual - %+v vs Expected - %+v", actual, expected)
+	}
+	if s := actual.String(); s != "seen 2/4 (50.0%), caught 1/4 (25.0%)" {
+		t.Errorf("Error - progress string. Actual - %q", s)
+	}
+}
+
+func TestCountProgressUsesSpecies(t *testing.T) {
+	c := &session.Session{}
+	toxtricity := session.CaughtPokemon{Pokemon: pokeapi.Pokemon{Name: "toxtricity-amped"}}
+	toxtricity.Pokemon.Species.Name = "toxtricity"
+	c.AddOwned(toxtricity)
+
+	// Pokedexes list species, not the names of their forms.
+	actual := countProgress(c, []string{"toxel", "toxtricity"})
+	expected := dexProgress{Seen: 1, Caught: 1, Total: 2}
+	if actual != expected {
+		t.Errorf("Error - progress. Actual - %+v vs Expected - %+v", actual, expected)
+	}
+}
+
+func TestPercentOfNothing(t *testing.T) {
+	if actual := percent(3, 0); actual != 0 {
+		t.Errorf("Error - percent of an empty pokedex. Actual - %v vs Expected - 0", actual)
+	}
+}
@@ -1,699 +1,540 @@
 package main
 
 import (
+	"context"
 	"strings"
 	"fmt"
 	"bufio"
-	"os"
-	"net/http"
-	"encoding/json"
 	"io"
+	"os"
+	"os/signal"
+	"unicode"
+	"sync"
 	"errors"
-	"math/rand"
-	"github.com/smwalke83/pokedex/internal/pokecache"
+	"sort"
+	"net/http"
+	"net/url"
+	"github.com/smwalke83/pokedex/internal/capture"
+	"github.com/smwalke83/pokedex/internal/command"
+	"github.com/smwalke83/pokedex/internal/fuzzy"
+	"github.com/smwalke83/pokedex/internal/inventory"
+	"github.com/smwalke83/pokedex/internal/lineedit"
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/session"
+	"github.com/smwalke83/pokedex/internal/stats"
 )
 
-func getCommands() map[string]cliCommand {
-	return map[string]cliCommand {
-		"exit": {
-			name:		 "exit",
-			description: "Exit the Pokedex",
-			callback:	 commandExit,
-		},
-		"help": {
-			name:		 "help",
-			description: "Displays a help message",
-			callback:	 commandHelp,
-		},
-		"map": {
-			name:		 "map",
-			description: "Shows the next 20 map locations",
-			callback:	 commandMap,
-		},
-		"mapb": {
-			name: 		 "mapb",
-			description: "Shows the previous 20 map locations",
-			callback:	 commandMapb,
-		},
-		"explore": {
-			name:		 "explore",
-			description: "Shows a list of all the Pokemon in the provided map location",
-			callback:	 commandExplore,
-		},
-		"catch": {
-			name:		 "catch",
-			description: "Throw a pokeball at a pokemon",
-			callback:	 commandCatch,
-		},
-		"inspect": {
-			name:		 "inspect",
-			description: "Learn about a pokemon in your pokedex",
-			callback:	 commandInspect,
-		},
-		"pokedex": {
-			name:		 "pokedex",
-			description: "View the pokemon you've added to your pokedex",
-			callback:	 commandPokedex,
-		},
+var errExit = errors.New("exit")
+
+// startRepl reads commands from in until EOF or the exit command and returns
+// the process exit code. Interactive sessions show a prompt; otherwise the
+// commands run as a script and the exit code is 1 if any of them failed.
+func startRepl(c *session.Session, in io.Reader, interactive bool) int {
+	interrupts := &interruptHandler{interactive: interactive}
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt)
+	defer signal.Stop(sigs)
+	go interrupts.listen(sigs)
+	lines := newLineReader(c, in, interactive)
+	failed := false
+	for {
+		line, err := lines.ReadLine()
+		if errors.Is(err, lineedit.ErrInterrupted) {
+			fmt.Println("(type exit to quit)")
+			continue
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				fmt.Printf("Error: %v\n", err)
+				failed = !interactive
+			}
+			if interactive {
+				fmt.Println()
+				commandExit(context.Background(), c, nil, nil)
+			} else if c.Autosave {
+				err := c.Save(c.SavePath)
+				if err != nil {
+					fmt.Printf("Autosave failed: %v\n", err)
+					failed = true
+				}
+			}
+			break
+		}
+		for _, input := range splitCommands(line) {
+			err := execute(c, interrupts, input)
+			if errors.Is(err, errExit) {
+				return exitCode(failed)
+			}
+			// Mistakes typed at the prompt are shown as they happen and
+			// don't make the session fail.
+			if err != nil && !interactive {
+				failed = true
+			}
+			if !interactive && errors.Is(err, context.Canceled) {
+				return 130
+			}
+		}
 	}
+	return exitCode(failed)
 }
 
+type lineReader interface {
+	ReadLine() (string, error)
+}
 
-type cliCommand struct {
-	name		string
-	description string
-	callback 	func(c *Config, cache *pokecache.Cache, s string, pokedex map[string]PokeData) (*Config, error)
+// newLineReader reads from the line editor when in is an interactive
+// terminal, with tab completion and the history in c.HistoryPath, and reads
+// plain lines otherwise.
+func newLineReader(c *session.Session, in io.Reader, interactive bool) lineReader {
+	if f, ok := in.(*os.File); ok && interactive {
+		e, err := lineedit.NewTerminal(f, os.Stdout)
+		if err == nil {
+			e.Prompt = "Pokedex > "
+			e.Complete = completer(c)
+			if c.HistoryPath != "" {
+				history, err := lineedit.LoadHistory(c.HistoryPath, lineedit.DefaultHistorySize)
+				if err != nil {
+					fmt.Printf("Error loading command history: %v\n", err)
+				} else {
+					e.History = history
+				}
+			}
+			return e
+		}
+	}
+	return &scanReader{scan: bufio.NewScanner(in), prompt: interactive}
 }
 
-type Config struct {
-	Count		int		`json:"count"`
-	Next		string	`json:"next"`
-	Previous	*string	`json:"previous"`
-	Results		[]struct {
-		Name	string	`json:"name"`
-		Url		string	`json:"url"`
-	} `json:"results"`
+// scanReader reads plain lines, for scripts and for terminals the line
+// editor can't drive.
+type scanReader struct {
+	scan   *bufio.Scanner
+	prompt bool
 }
 
-type LocationData struct {
-	ID                   int    `json:"id"`
-	Name                 string `json:"name"`
-	GameIndex            int    `json:"game_index"`
-	EncounterMethodRates []struct {
-		EncounterMethod struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"encounter_method"`
-		VersionDetails []struct {
-			Rate    int `json:"rate"`
-			Version struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
-		} `json:"version_details"`
-	} `json:"encounter_method_rates"`
-	Location struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"location"`
-	Names []struct {
-		Name     string `json:"name"`
-		Language struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"language"`
-	} `json:"names"`
-	PokemonEncounters []struct {
-		Pokemon struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"pokemon"`
-		VersionDetails []struct {
-			Version struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
-			MaxChance        int `json:"max_chance"`
-			EncounterDetails []struct {
-				MinLevel        int   `json:"min_level"`
-				MaxLevel        int   `json:"max_level"`
-				ConditionValues []any `json:"condition_values"`
-				Chance          int   `json:"chance"`
-				Method          struct {
-					Name string `json:"name"`
-					URL  string `json:"url"`
-				} `json:"method"`
-			} `json:"encounter_details"`
-		} `json:"version_details"`
-	} `json:"pokemon_encounters"`
+func (r *scanReader) ReadLine() (string, error) {
+	if r.prompt {
+		fmt.Print("Pokedex > ")
+	}
+	if r.scan.Scan() {
+		return r.scan.Text(), nil
+	}
+	if err := r.scan.Err(); err != nil {
+		return "", err
+	}
+	return "", io.EOF
 }
 
-type PokeData struct {
-	ID             int    `json:"id"`
-	Name           string `json:"name"`
-	BaseExperience int    `json:"base_experience"`
-	Height         int    `json:"height"`
-	IsDefault      bool   `json:"is_default"`
-	Order          int    `json:"order"`
-	Weight         int    `json:"weight"`
-	Abilities      []struct {
-		IsHidden bool `json:"is_hidden"`
-		Slot     int  `json:"slot"`
-		Ability  struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"ability"`
-	} `json:"abilities"`
-	Forms []struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"forms"`
-	GameIndices []struct {
-		GameIndex int `json:"game_index"`
-		Version   struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"version"`
-	} `json:"game_indices"`
-	HeldItems []struct {
-		Item struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"item"`
-		VersionDetails []struct {
-			Rarity  int `json:"rarity"`
-			Version struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
-		} `json:"version_details"`
-	} `json:"held_items"`
-	LocationAreaEncounters string `json:"location_area_encounters"`
-	Moves                  []struct {
-		Move struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"move"`
-		VersionGroupDetails []struct {
-			LevelLearnedAt int `json:"level_learned_at"`
-			VersionGroup   struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version_group"`
-			MoveLearnMethod struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"move_learn_method"`
-			Order int `json:"order"`
-		} `json:"version_group_details"`
-	} `json:"moves"`
-	Species struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"species"`
-	Sprites struct {
-		BackDefault      string `json:"back_default"`
-		BackFemale       any    `json:"back_female"`
-		BackShiny        string `json:"back_shiny"`
-		BackShinyFemale  any    `json:"back_shiny_female"`
-		FrontDefault     string `json:"front_default"`
-		FrontFemale      any    `json:"front_female"`
-		FrontShiny       string `json:"front_shiny"`
-		FrontShinyFemale any    `json:"front_shiny_female"`
-		Other            struct {
-			DreamWorld struct {
-				FrontDefault string `json:"front_default"`
-				FrontFemale  any    `json:"front_female"`
-			} `json:"dream_world"`
-			Home struct {
-				FrontDefault     string `json:"front_default"`
-				FrontFemale      any    `json:"front_female"`
-				FrontShiny       string `json:"front_shiny"`
-				FrontShinyFemale any    `json:"front_shiny_female"`
-			} `json:"home"`
-			OfficialArtwork struct {
-				FrontDefault string `json:"front_default"`
-				FrontShiny   string `json:"front_shiny"`
-			} `json:"official-artwork"`
-			Showdown struct {
-				BackDefault      string `json:"back_default"`
-				BackFemale       any    `json:"back_female"`
-				BackShiny        string `json:"back_shiny"`
-				BackShinyFemale  any    `json:"back_shiny_female"`
-				FrontDefault     string `json:"front_default"`
-				FrontFemale      any    `json:"front_female"`
-				FrontShiny       string `json:"front_shiny"`
-				FrontShinyFemale any    `json:"front_shiny_female"`
-			} `json:"showdown"`
-		} `json:"other"`
-		Versions struct {
-			GenerationI struct {
-				RedBlue struct {
-					BackDefault  string `json:"back_default"`
-					BackGray     string `json:"back_gray"`
-					FrontDefault string `json:"front_default"`
-					FrontGray    string `json:"front_gray"`
-				} `json:"red-blue"`
-				Yellow struct {
-					BackDefault  string `json:"back_default"`
-					BackGray     string `json:"back_gray"`
-					FrontDefault string `json:"front_default"`
-					FrontGray    string `json:"front_gray"`
-				} `json:"yellow"`
-			} `json:"generation-i"`
-			GenerationIi struct {
-				Crystal struct {
-					BackDefault  string `json:"back_default"`
-					BackShiny    string `json:"back_shiny"`
-					FrontDefault string `json:"front_default"`
-					FrontShiny   string `json:"front_shiny"`
-				} `json:"crystal"`
-				Gold struct {
-					BackDefault  string `json:"back_default"`
-					BackShiny    string `json:"back_shiny"`
-					FrontDefault string `json:"front_default"`
-					FrontShiny   string `json:"front_shiny"`
-				} `json:"gold"`
-				Silver struct {
-					BackDefault  string `json:"back_default"`
-					BackShiny    string `json:"back_shiny"`
-					FrontDefault string `json:"front_default"`
-					FrontShiny   string `json:"front_shiny"`
-				} `json:"silver"`
-			} `json:"generation-ii"`
-			GenerationIii struct {
-				Emerald struct {
-					FrontDefault string `json:"front_default"`
-					FrontShiny   string `json:"front_shiny"`
-				} `json:"emerald"`
-				FireredLeafgreen struct {
-					BackDefault  string `json:"back_default"`
-					BackShiny    string `json:"back_shiny"`
-					FrontDefault string `json:"front_default"`
-					FrontShiny   string `json:"front_shiny"`
-				} `json:"firered-leafgreen"`
-				RubySapphire struct {
-					BackDefault  string `json:"back_default"`
-					BackShiny    string `json:"back_shiny"`
-					FrontDefault string `json:"front_default"`
-					FrontShiny   string `json:"front_shiny"`
-				} `json:"ruby-sapphire"`
-			} `json:"generation-iii"`
-			GenerationIv struct {
-				DiamondPearl struct {
-					BackDefault      string `json:"back_default"`
-					BackFemale       any    `json:"back_female"`
-					BackShiny        string `json:"back_shiny"`
-					BackShinyFemale  any    `json:"back_shiny_female"`
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      any    `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale any    `json:"front_shiny_female"`
-				} `json:"diamond-pearl"`
-				HeartgoldSoulsilver struct {
-					BackDefault      string `json:"back_default"`
-					BackFemale       any    `json:"back_female"`
-					BackShiny        string `json:"back_shiny"`
-					BackShinyFemale  any    `json:"back_shiny_female"`
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      any    `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale any    `json:"front_shiny_female"`
-				} `json:"heartgold-soulsilver"`
-				Platinum struct {
-					BackDefault      string `json:"back_default"`
-					BackFemale       any    `json:"back_female"`
-					BackShiny        string `json:"back_shiny"`
-					BackShinyFemale  any    `json:"back_shiny_female"`
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      any    `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale any    `json:"front_shiny_female"`
-				} `json:"platinum"`
-			} `json:"generation-iv"`
-			GenerationV struct {
-				BlackWhite struct {
-					Animated struct {
-						BackDefault      string `json:"back_default"`
-						BackFemale       any    `json:"back_female"`
-						BackShiny        string `json:"back_shiny"`
-						BackShinyFemale  any    `json:"back_shiny_female"`
-						FrontDefault     string `json:"front_default"`
-						FrontFemale      any    `json:"front_female"`
-						FrontShiny       string `json:"front_shiny"`
-						FrontShinyFemale any    `json:"front_shiny_female"`
-					} `json:"animated"`
-					BackDefault      string `json:"back_default"`
-					BackFemale       any    `json:"back_female"`
-					BackShiny        string `json:"back_shiny"`
-					BackShinyFemale  any    `json:"back_shiny_female"`
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      any    `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale any    `json:"front_shiny_female"`
-				} `json:"black-white"`
-			} `json:"generation-v"`
-			GenerationVi struct {
-				OmegarubyAlphasapphire struct {
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      any    `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale any    `json:"front_shiny_female"`
-				} `json:"omegaruby-alphasapphire"`
-				XY struct {
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      any    `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale any    `json:"front_shiny_female"`
-				} `json:"x-y"`
-			} `json:"generation-vi"`
-			GenerationVii struct {
-				Icons struct {
-					FrontDefault string `json:"front_default"`
-					FrontFemale  any    `json:"front_female"`
-				} `json:"icons"`
-				UltraSunUltraMoon struct {
-					FrontDefault     string `json:"front_default"`
-					FrontFemale      any    `json:"front_female"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontShinyFemale any    `json:"front_shiny_female"`
-				} `json:"ultra-sun-ultra-moon"`
-			} `json:"generation-vii"`
-			GenerationViii struct {
-				Icons struct {
-					FrontDefault string `json:"front_default"`
-					FrontFemale  any    `json:"front_female"`
-				} `json:"icons"`
-			} `json:"generation-viii"`
-		} `json:"versions"`
-	} `json:"sprites"`
-	Cries struct {
-		Latest string `json:"latest"`
-		Legacy string `json:"legacy"`
-	} `json:"cries"`
-	Stats []struct {
-		BaseStat int `json:"base_stat"`
-		Effort   int `json:"effort"`
-		Stat     struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"stat"`
-	} `json:"stats"`
-	Types []struct {
-		Slot int `json:"slot"`
-		Type struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"type"`
-	} `json:"types"`
-	PastTypes []struct {
-		Generation struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"generation"`
-		Types []struct {
-			Slot int `json:"slot"`
-			Type struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"type"`
-		} `json:"types"`
-	} `json:"past_types"`
-	PastAbilities []struct {
-		Generation struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"generation"`
-		Abilities []struct {
-			Ability  any  `json:"ability"`
-			IsHidden bool `json:"is_hidden"`
-			Slot     int  `json:"slot"`
-		} `json:"abilities"`
-	} `json:"past_abilities"`
+func exitCode(failed bool) int {
+	if failed {
+		return 1
+	}
+	return 0
 }
 
-func startRepl(cache *pokecache.Cache) {
-	c := new(Config)
-	pokedex := make(map[string]PokeData)
-	scan := bufio.NewScanner(os.Stdin)
-	for {
-		fmt.Print("Pokedex > ")
-		ok := scan.Scan()
-		if !ok {
-			fmt.Printf("Error: %v\n", scan.Err())
-		}
-		input := scan.Text()
-		wordSlice := cleanInput(input)
-		parameter := ""
-		if len(wordSlice) > 1 {
-			parameter = wordSlice[1]
+// execute runs a single command line and prints any error it returns.
+func execute(c *session.Session, interrupts *interruptHandler, input string) error {
+	wordSlice := cleanInput(input)
+	if len(wordSlice) == 0 || strings.HasPrefix(wordSlice[0], "#") {
+		return nil
+	}
+	cmd, err := lookupCommand(wordSlice[0])
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+	args, flags, err := command.Parse(cmd.Spec(), wordSlice[1:])
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+	err = runCommand(c, interrupts, cmd, args, flags)
+	if err != nil && !errors.Is(err, errExit) {
+		fmt.Println(renderError(c, err))
+	}
+	return err
+}
+
+// renderError returns the message shown for an error a command returned.
+// Errors from the API are explained rather than shown as a URL and status.
+func renderError(c *session.Session, err error) string {
+	var httpErr *pokeapi.HTTPError
+	var networkErr *pokeapi.NetworkError
+	var decodeErr *pokeapi.DecodeError
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "Command cancelled."
+	case errors.Is(err, context.DeadlineExceeded):
+		return fmt.Sprintf("Command timed out after %v.", c.Timeout)
+	case errors.As(err, &httpErr) && errors.Is(err, pokeapi.ErrRateLimited):
+		if httpErr.RetryAfter > 0 {
+			return fmt.Sprintf("The PokeAPI is getting too many requests - wait %v and try again.", httpErr.RetryAfter)
 		}
-		word, ok := getCommands()[wordSlice[0]]
-		if !ok {
-			fmt.Println("Unknown command")
-			continue
+		return "The PokeAPI is getting too many requests - wait a minute and try again."
+	case errors.As(err, &httpErr) && errors.Is(err, pokeapi.ErrNotFound):
+		return fmt.Sprintf("The PokeAPI has no %s - check the spelling.", describeResource(c, httpErr.URL))
+	case errors.As(err, &httpErr):
+		return fmt.Sprintf("The PokeAPI couldn't answer for %s (%d %s) - try again later.", describeResource(c, httpErr.URL), httpErr.StatusCode, http.StatusText(httpErr.StatusCode))
+	case errors.As(err, &networkErr):
+		cause := networkErr.Err
+		var urlErr *url.Error
+		if errors.As(cause, &urlErr) {
+			cause = urlErr.Err
 		}
-		new_c, err := word.callback(c, cache, parameter, pokedex)
+		return fmt.Sprintf("Couldn't reach the PokeAPI at %s - check your connection or -base-url (%v).", c.Client.BaseURL(), cause)
+	case errors.As(err, &decodeErr):
+		return fmt.Sprintf("The PokeAPI sent a response for %s that couldn't be read - is -base-url right?", describeResource(c, decodeErr.URL))
+	}
+	return err.Error()
+}
+
+// describeResource names the resource at an API URL, e.g. pokemon "pikachu"
+// for .../pokemon/pikachu/ or location area list for a page of
+// .../location-area, falling back to the URL itself.
+func describeResource(c *session.Session, u string) string {
+	path, ok := strings.CutPrefix(u, c.Client.BaseURL()+"/")
+	if !ok {
+		return u
+	}
+	path, _, _ = strings.Cut(path, "?")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch len(parts) {
+	case 1:
+		return strings.ReplaceAll(parts[0], "-", " ") + " list"
+	case 2:
+		name, err := url.PathUnescape(parts[1])
 		if err != nil {
-			fmt.Println(err)
+			name = parts[1]
+		}
+		return fmt.Sprintf("%s %q", strings.ReplaceAll(parts[0], "-", " "), name)
+	}
+	return u
+}
+
+// splitCommands splits a line into the commands separated by semicolons,
+// ignoring semicolons inside quotes.
+func splitCommands(line string) []string {
+	commands := []string{}
+	start := 0
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ';':
+			commands = append(commands, line[start:i])
+			start = i + 1
+		}
+	}
+	return append(commands, line[start:])
+}
+
+// runCommand runs cmd under its own context, which is cancelled by the
+// command timeout or by an interrupt arriving while the command is running.
+func runCommand(c *session.Session, interrupts *interruptHandler, cmd command.Command, args []string, flags map[string]string) error {
+	ctx := context.Background()
+	var cancel context.CancelFunc
+	if c.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+	interrupts.set(cancel)
+	defer interrupts.set(nil)
+	return cmd.Run(ctx, c, args, flags)
+}
+
+type interruptHandler struct {
+	mu			sync.Mutex
+	cancel		context.CancelFunc
+	interactive	bool
+}
+
+func (h *interruptHandler) set(cancel context.CancelFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.cancel = cancel
+}
+
+func (h *interruptHandler) listen(sigs <-chan os.Signal) {
+	for range sigs {
+		h.mu.Lock()
+		if h.cancel != nil {
+			fmt.Println()
+			h.cancel()
+		} else if !h.interactive {
+			os.Exit(130)
+		} else {
+			fmt.Print("\n(type exit to quit)\nPokedex > ")
 		}
-		*c = *new_c
+		h.mu.Unlock()
 	}
 }
 
+// cleanInput splits text into words on runs of whitespace. Single or double
+// quotes group words together and keep their case; everything outside
+// quotes is lowercased.
 func cleanInput(text string) []string {
-	words := strings.Split(strings.ToLower(strings.TrimSpace(text)), " ")
+	words := []string{}
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	for _, r := range text {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			word.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			inWord = true
+		case unicode.IsSpace(r):
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(unicode.ToLower(r))
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
 	return words
 }
 
-func commandExit(c *Config, _ *pokecache.Cache, s string, _ map[string]PokeData) (*Config, error) {
-	if len(s) > 0 {
-		fmt.Println("Invalid command - Exit does not accept additional parameters.")
-		return c, nil
+func commandExit(_ context.Context, c *session.Session, args []string, _ map[string]string) error {
+	if c.Autosave {
+		err := c.Save(c.SavePath)
+		if err != nil {
+			fmt.Printf("Autosave failed: %v\n", err)
+		}
 	}
 	fmt.Println("Closing the Pokedex... Goodbye!")
-	os.Exit(0)
-	return c, nil
+	return errExit
 }
 
-func commandHelp(c *Config, _ *pokecache.Cache, s string, _ map[string]PokeData) (*Config, error) {
-	if len(s) > 0 {
-		fmt.Println("Help command does not accept additional parameters - displaying help menu.")
+func commandHelp(_ context.Context, c *session.Session, args []string, _ map[string]string) error {
+	if len(args) > 0 {
+		cmd, ok := command.Default.Lookup(args[0])
+		if !ok {
+			return fmt.Errorf("Invalid command - there is no %q command, use help to list them.", args[0])
+		}
+		fmt.Print(cmd.Spec().Help())
+		return nil
 	}
 	fmt.Println("Welcome to the Pokedex!")
 	fmt.Println("Usage:")
-	for key, value := range getCommands() {
-		fmt.Printf("%s: %s\n", key, value.description)
+	commands := command.Default.Commands()
+	for _, category := range command.Categories {
+		fmt.Printf("\n%s%s:\n", strings.ToUpper(string(category[:1])), category[1:])
+		for _, cmd := range commands {
+			spec := cmd.Spec()
+			if spec.Category == category {
+				fmt.Printf("  %s: %s\n", spec.Usage, spec.Description)
+			}
+		}
 	}
-	return c, nil
+	fmt.Println("\nUse help <command> for its arguments, flags and examples.")
+	return nil
 }
 
-func commandMap(c *Config, cache *pokecache.Cache, s string, _ map[string]PokeData) (*Config, error) {
-	if len(s) > 0 {
-		fmt.Println("Invalid command - Map does not accept additional parameters.")
-		return c, nil
+func commandMap(ctx context.Context, c *session.Session, args []string, _ map[string]string) error {
+	var pageURL *string
+	if c.Next != "" {
+		pageURL = &c.Next
 	}
-	c, err := getLocations(c, cache)
+	list, err := c.Client.ListLocationAreas(ctx, pageURL)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-		return c, err
+		return err
 	}
-	for _, result := range c.Results {
+	setPage(c, list)
+	for _, result := range list.Results {
 		fmt.Printf("%s\n", result.Name)
 	}
-	return c, nil
+	return nil
 }
 
-func commandMapb(c *Config, cache *pokecache.Cache, s string, _ map[string]PokeData) (*Config, error) {
-	if len(s) > 0 {
-		fmt.Println("Invalid command - Map does not accept additional parameters.")
-		return c, nil
-	}
+func commandMapb(ctx context.Context, c *session.Session, args []string, _ map[string]string) error {
 	if c.Previous == nil {
 		fmt.Println("You're on the first page.")
-		return c, nil
+		return nil
 	}
-	c, err := getLocationsb(c, cache)
+	list, err := c.Client.ListLocationAreas(ctx, c.Previous)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-		return c, err
+		return err
 	}
-	for _, result := range c.Results {
+	setPage(c, list)
+	for _, result := range list.Results {
 		fmt.Printf("%s\n", result.Name)
 	}
-	return c, nil
+	return nil
 }
 
-func getLocations(c *Config, cache *pokecache.Cache) (*Config, error) {
-	url := c.Next
-	var body []byte
-	if c.Next == "" {
-		url = "https://pokeapi.co/api/v2/location-area"
-	}
-	var new_c Config
-	val, ok := cache.Get(url)
-	if ok {
-		body = val
-	} else {
-		res, err := http.Get(url)
-		if err != nil {
-			return &new_c, err
-		}
-		body, err = io.ReadAll(res.Body)
-		if err != nil {
-			return &new_c, err
-		}
-		res.Body.Close()
-		if res.StatusCode > 299 {
-			return &new_c, fmt.Errorf("Error: Status Code %v", res.StatusCode)
-		}
-		cache.Add(url, body)
+func setPage(c *session.Session, list pokeapi.LocationAreaList) {
+	c.Next = ""
+	if list.Next != nil {
+		c.Next = *list.Next
 	}
-	err := json.Unmarshal(body, &new_c)
-	if err != nil {
-		return &new_c, err
+	c.Previous = list.Previous
+	c.Areas = c.Areas[:0]
+	for _, result := range list.Results {
+		c.Areas = append(c.Areas, result.Name)
 	}
-	return &new_c, nil
 }
 
-func getLocationsb(c *Config, cache *pokecache.Cache) (*Config, error) {
-	var url string
-	var new_c Config
-	var body []byte
-	if c.Previous == nil {
-		return c, nil
-	} else {
-		url = *c.Previous
+func commandCatch(ctx context.Context, c *session.Session, args []string, flags map[string]string) error {
+	if c.Wild == nil {
+		return errors.New("There's no wild Pokemon here - use encounter to look for one.")
 	}
-	val, ok := cache.Get(url)
-	if ok {
-		body = val
-	} else {
-		res, err := http.Get(url)
-		if err != nil {
-			return &new_c, err
-		}
-		body, err = io.ReadAll(res.Body)
-		if err != nil {
-			return &new_c, err
-		}
-		res.Body.Close()
-		if res.StatusCode > 299 {
-			return &new_c, fmt.Errorf("Error: Status Code %v", res.StatusCode)
+	name := c.Wild.Name
+	if len(args) > 0 {
+		// Only the pokemon being faced can be caught, but it can be
+		// shortened.
+		if _, err := fuzzy.NewIndex("pokemon", []string{name}).Lookup(args[0]); err != nil {
+			return fmt.Errorf("There's no wild %s here - you're facing a %s.", args[0], name)
 		}
-		cache.Add(url, body)
 	}
-	err := json.Unmarshal(body, &new_c)
-	if err != nil {
-		return &new_c, err
+	ball := inventory.DefaultBall
+	if flags["ball"] != "" {
+		ball = flags["ball"]
 	}
-	return &new_c, nil
-}
-
-func commandExplore(c *Config, cache *pokecache.Cache, s string, _ map[string]PokeData) (*Config, error) {
-	if len(s) == 0 {
-		err := errors.New("You must provide a location parameter.")
-		return c, err
+	ballModifier, ok := inventory.BallModifier(ball)
+	if !ok {
+		return fmt.Errorf("%s is not a Poke Ball.", ball)
 	}
-	loc, err := getLocData(c, cache, s)
-	if err != nil {
-		return c, err
+	if c.Bag.Count(ball) == 0 {
+		return fmt.Errorf("You don't have any %s left.", ball)
 	}
-	for _, result := range loc.PokemonEncounters {
-		fmt.Printf("%s\n", result.Pokemon.Name)
+	poke, err := c.Client.GetPokemon(ctx, name)
+	if err != nil {
+		return err
 	}
-	return c, err
-}
-
-func getLocData(_ *Config, cache *pokecache.Cache, s string) (*LocationData, error) {
-	url := "https://pokeapi.co/api/v2/location-area/" + s + "/"
-	var loc LocationData
-	var body []byte
-	val, ok := cache.Get(url)
-	if ok {
-		body = val
-	} else {
-		res, err := http.Get(url)
-		if err != nil {
-			return &loc, err
-		}
-		body, err = io.ReadAll(res.Body)
-		if err != nil {
-			return &loc, err
-		}
-		res.Body.Close()
-		if res.StatusCode > 299 {
-			return &loc, fmt.Errorf("Error: Status Code %v", res.StatusCode)
-		}
-		cache.Add(url, body)
+	species, err := c.Client.GetPokemonSpecies(ctx, poke.Species.Name)
+	if err != nil {
+		return err
 	}
-	err := json.Unmarshal(body, &loc)
+	err = c.Bag.Use(ball)
 	if err != nil {
-		return &loc, err
+		return err
 	}
-	return &loc, nil
-}
-
-func commandCatch(c *Config, cache *pokecache.Cache, s string, pokedex map[string]PokeData) (*Config, error) {
-	if len(s) == 0 {
-		err := errors.New("Please enter the name of the Pokemon you wish to catch")
-		return c, err
+	// A wild pokemon that hasn't been attacked is at full health.
+	maxHP, currentHP := stats.Base(poke).HP, stats.Base(poke).HP
+	if c.Wild.MaxHP > 0 {
+		maxHP, currentHP = c.Wild.MaxHP, c.Wild.HP
 	}
-	poke, err := getPokeData(cache, s)
-	if err != nil {
-		return c, err
+	result := capture.Attempt(c.RNG, capture.Params{
+		CaptureRate: species.CaptureRate,
+		MaxHP: maxHP,
+		CurrentHP: currentHP,
+		Ball: ballModifier,
+		Status: capture.StatusOf(c.Wild.Status),
+	})
+	fmt.Printf("Throwing a %s at %s...\n", ball, name)
+	for i := 0; i < result.Shakes; i++ {
+		fmt.Println("The ball shook...")
 	}
-	fmt.Printf("Throwing a Pokeball at %s...\n", s)
-	randomNumber := rand.Intn(poke.BaseExperience)
-	if randomNumber < 40 {
-		fmt.Printf("%s was caught!\n", s)
-		fmt.Printf("You may now inspect it with the inspect command.\n")
-		_, ok := pokedex[s]
-		if !ok {
-			pokedex[s] = *poke
+	if result.Caught {
+		caught := c.AddOwned(c.NewCaught(poke, species, c.Wild.Level))
+		fmt.Printf("%s was caught!\n", name)
+		if c.InParty(caught.ID) {
+			fmt.Printf("%s was added to your party.\n", caught.Label())
+		} else {
+			fmt.Printf("Your party is full - %s was sent to the box.\n", caught.Label())
 		}
+		fmt.Printf("You may now inspect it with the inspect command.\n")
+		c.Wild = nil
 	} else {
-		fmt.Printf("%s escaped!\n", s)
+		c.MarkSeen(species.Name)
+		fmt.Printf("%s escaped!\n", name)
 	}
-	return c, nil
+	return nil
 }
 
-func getPokeData(cache *pokecache.Cache, s string) (*PokeData, error) {
-	url := "https://pokeapi.co/api/v2/pokemon/" + s + "/"
-	var poke PokeData
-	var body []byte
-	val, ok := cache.Get(url)
-	if ok {
-		body = val
-	} else {
-		res, err := http.Get(url)
-		if err != nil {
-			return &poke, err
-		}
-		body, err = io.ReadAll(res.Body)
+func commandBag(ctx context.Context, c *session.Session, args []string, _ map[string]string) error {
+	fmt.Println("Your Bag:")
+	fmt.Printf("Money: %d\n", c.Money)
+	items := c.Bag.Items()
+	if len(items) == 0 {
+		fmt.Println("Your bag is empty!")
+	}
+	for _, name := range items {
+		item, err := c.Client.GetItem(ctx, name)
 		if err != nil {
-			return &poke, err
+			return err
 		}
-		res.Body.Close()
-		if res.StatusCode > 299 {
-			return &poke, fmt.Errorf("Error: Status Code %v", res.StatusCode)
-		}
-		cache.Add(url, body)
-	}
-	err := json.Unmarshal(body, &poke)
-	if err != nil {
-		return &poke, err
+		description := strings.Join(strings.Fields(item.Description()), " ")
+		fmt.Printf(" - %s x%d: %s\n", item.DisplayName(), c.Bag.Count(name), description)
 	}
-	return &poke, nil
+	return nil
 }
 
-func commandInspect(c *Config, _ *pokecache.Cache, s string, pokedex map[string]PokeData) (*Config, error) {
-	pokemon, ok := pokedex[s]
-	if !ok {
-		fmt.Printf("you have not caught that pokemon\n")
-	} else {
-		fmt.Printf("Name: %v\n", pokemon.Name)
-		fmt.Printf("Height: %v\n", pokemon.Height)
-		fmt.Printf("Weight: %v\n", pokemon.Weight)
-		fmt.Printf("Stats:\n")
-		for _, stat := range pokemon.Stats {
-			fmt.Printf("  -%v: %v\n", stat.Stat.Name, stat.BaseStat)
-		}
-		fmt.Printf("Types:\n")
-		for _, t := range pokemon.Types {
-			fmt.Printf("  -%v\n", t.Type.Name)
+
+func commandInspect(_ context.Context, c *session.Session, args []string, _ map[string]string) error {
+	caught, err := c.FindOwned(args[0])
+	if err != nil {
+		return err
+	}
+	pokemon := caught.Pokemon
+	fmt.Printf("ID: %v\n", caught.ID)
+	if caught.Nickname != "" {
+		fmt.Printf("Nickname: %v\n", caught.Nickname)
+	}
+	fmt.Printf("Name: %v\n", pokemon.Name)
+	fmt.Printf("Height: %v\n", pokemon.Height)
+	fmt.Printf("Weight: %v\n", pokemon.Weight)
+	fmt.Printf("Level: %v\n", caught.CurrentLevel())
+	fmt.Printf("Friendship: %v\n", caught.Friendship)
+	if caught.GrowthRate != "" {
+		if next, err := stats.Experience(caught.GrowthRate, caught.CurrentLevel()+1); err == nil && caught.CurrentLevel() < stats.MaxLevel {
+			fmt.Printf("Experience: %v (%v to next level)\n", caught.Experience, next-caught.Experience)
+		} else {
+			fmt.Printf("Experience: %v\n", caught.Experience)
 		}
 	}
-	return c, nil
+	nature := caught.Nature()
+	if nature.Name != "" && !nature.Neutral() {
+		fmt.Printf("Nature: %v (+%v, -%v)\n", nature.Name, nature.Increased, nature.Decreased)
+	} else if nature.Name != "" {
+		fmt.Printf("Nature: %v\n", nature.Name)
+	}
+	actual := caught.Stats()
+	fmt.Printf("Stats:\n")
+	for _, stat := range pokemon.Stats {
+		name := stat.Stat.Name
+		fmt.Printf("  -%v: %v (base %v, IV %v, EV %v)\n", name, actual.Get(name), stat.BaseStat, caught.IVs.Get(name), caught.EVs.Get(name))
+	}
+	fmt.Printf("Types:\n")
+	for _, t := range pokemon.Types {
+		fmt.Printf("  -%v\n", t.Type.Name)
+	}
+	return nil
 }
 
-func commandPokedex(c *Config, _ *pokecache.Cache, _ string, pokedex map[string]PokeData) (*Config, error) {
+func commandPokedex(_ context.Context, c *session.Session, _ []string, _ map[string]string) error {
 	fmt.Println("Your Pokedex:")
-	if len(pokedex) == 0 {
+	owned := make(map[string]int)
+	for _, caught := range c.Owned {
+		owned[caught.SpeciesName()]++
+	}
+	species := []string{}
+	for name, entry := range c.Pokedex {
+		if entry.Caught {
+			species = append(species, name)
+		}
+	}
+	if len(species) == 0 {
 		fmt.Println("You haven't caught any pokemon!")
 	}
-	for key, _ := range pokedex {
-		fmt.Printf(" - %s\n", key)
+	sort.Strings(species)
+	for _, name := range species {
+		fmt.Printf(" - %s (%d owned)\n", name, owned[name])
 	}
-	return c, nil
-}
\ No newline at end of file
+	return nil
+}
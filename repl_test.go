@@ -1,7 +1,16 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/session"
 )
 
 func TestCleanInput(t *testing.T) {
@@ -21,6 +30,22 @@ func TestCleanInput(t *testing.T) {
 			input: "Charmander Bulbasaur PIKACHU",
 			expected: []string{"charmander", "bulbasaur", "pikachu"},
 		},
+		{
+			input: "catch  \t pikachu   ",
+			expected: []string{"catch", "pikachu"},
+		},
+		{
+			input: `nickname 1 "Sir Sparks" 'a b'`,
+			expected: []string{"nickname", "1", "Sir Sparks", "a b"},
+		},
+		{
+			input: `save --file="My Save.json" ""`,
+			expected: []string{"save", "--file=My Save.json", ""},
+		},
+		{
+			input: "",
+			expected: []string{},
+		},
 	}
 	for _, c := range cases {
 		actual := cleanInput(c.input)
@@ -35,4 +60,118 @@ func TestCleanInput(t *testing.T) {
 			}
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestSplitCommands(t *testing.T) {
+	cases := []struct {
+		input string
+		expected []string
+	}{
+		{
+			input: "catch pikachu; inspect pikachu",
+			expected: []string{"catch pikachu", " inspect pikachu"},
+		},
+		{
+			input: `nickname 1 "a;b";pokedex`,
+			expected: []string{`nickname 1 "a;b"`, "pokedex"},
+		},
+	}
+	for _, c := range cases {
+		actual := splitCommands(c.input)
+		if strings.Join(actual, "|") != strings.Join(c.expected, "|") {
+			t.Errorf("Error - Commands Don't Match: Actual - %q vs Expected - %q", actual, c.expected)
+		}
+	}
+}
+
+func TestStartReplExitCode(t *testing.T) {
+	cases := []struct {
+		input string
+		interactive bool
+		expected int
+	}{
+		{
+			input: "pokedex\nbag --help\n",
+			expected: 1,
+		},
+		{
+			input: "pokedex; help\n",
+			expected: 0,
+		},
+		{
+			input: "# a comment\n\npokedex; exit; bogus\n",
+			expected: 0,
+		},
+		{
+			input: "bogus\nexit\n",
+			expected: 1,
+		},
+		{
+			input: "bogus\nexit\n",
+			interactive: true,
+			expected: 0,
+		},
+	}
+	for _, c := range cases {
+		cfg := &session.Session{
+			Pokedex: make(map[string]session.DexEntry),
+		}
+		actual := startRepl(cfg, strings.NewReader(c.input), c.interactive)
+		if actual != c.expected {
+			t.Errorf("Error - Exit Codes Don't Match for %q (interactive %v): Actual - %d vs Expected - %d", c.input, c.interactive, actual, c.expected)
+		}
+	}
+}
+
+func TestRenderError(t *testing.T) {
+	c := &session.Session{
+		Client:  pokeapi.NewClient("http://127.0.0.1:8765", nil, nil),
+		Timeout: 30 * time.Second,
+	}
+	cases := []struct {
+		err error
+		expected string
+	}{
+		{
+			err: &pokeapi.HTTPError{URL: "http://127.0.0.1:8765/pokemon/pikahcu/", StatusCode: 404},
+			expected: `The PokeAPI has no pokemon "pikahcu" - check the spelling.`,
+		},
+		{
+			err: fmt.Errorf("evolving: %w", &pokeapi.HTTPError{URL: "http://127.0.0.1:8765/location-area/nowhere/", StatusCode: 404}),
+			expected: `The PokeAPI has no location area "nowhere" - check the spelling.`,
+		},
+		{
+			err: &pokeapi.HTTPError{URL: "http://127.0.0.1:8765/pokemon/pikachu/", StatusCode: 429},
+			expected: "The PokeAPI is getting too many requests - wait a minute and try again.",
+		},
+		{
+			err: &pokeapi.HTTPError{URL: "http://127.0.0.1:8765/pokemon/pikachu/", StatusCode: 429, RetryAfter: 30 * time.Second},
+			expected: "The PokeAPI is getting too many requests - wait 30s and try again.",
+		},
+		{
+			err: &pokeapi.HTTPError{URL: "http://127.0.0.1:8765/location-area?offset=20", StatusCode: 503},
+			expected: "The PokeAPI couldn't answer for location area list (503 Service Unavailable) - try again later.",
+		},
+		{
+			err: &pokeapi.NetworkError{URL: "http://127.0.0.1:8765/pokemon/pikachu/", Err: &url.Error{Op: "Get", URL: "http://127.0.0.1:8765/pokemon/pikachu/", Err: errors.New("connection refused")}},
+			expected: "Couldn't reach the PokeAPI at http://127.0.0.1:8765 - check your connection or -base-url (connection refused).",
+		},
+		{
+			err: &pokeapi.NetworkError{URL: "http://127.0.0.1:8765/pokemon/pikachu/", Err: context.DeadlineExceeded},
+			expected: "Command timed out after 30s.",
+		},
+		{
+			err: &pokeapi.DecodeError{URL: "http://127.0.0.1:8765/pokemon/pikachu/", Err: errors.New("invalid character '<'")},
+			expected: `The PokeAPI sent a response for pokemon "pikachu" that couldn't be read - is -base-url right?`,
+		},
+		{
+			err: errors.New("You don't have a Pokemon with ID 1."),
+			expected: "You don't have a Pokemon with ID 1.",
+		},
+	}
+	for _, tc := range cases {
+		if actual := renderError(c, tc.err); actual != tc.expected {
+			t.Errorf("Error - Messages Don't Match: Actual - %q vs Expected - %q", actual, tc.expected)
+		}
+	}
+}
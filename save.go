@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smwalke83/pokedex/internal/session"
+)
+
+func commandSave(_ context.Context, c *session.Session, args []string, _ map[string]string) error {
+	path := c.SavePath
+	if len(args) > 0 {
+		path = args[0]
+	}
+	if err := c.Save(path); err != nil {
+		return err
+	}
+	fmt.Printf("Saved %d pokemon to %s\n", len(c.Owned), path)
+	return nil
+}
+
+func commandLoad(_ context.Context, c *session.Session, args []string, _ map[string]string) error {
+	path := c.SavePath
+	if len(args) > 0 {
+		path = args[0]
+	}
+	if err := c.Load(path); err != nil {
+		return err
+	}
+	fmt.Printf("Loaded %d pokemon from %s\n", len(c.Owned), path)
+	return nil
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/smwalke83/pokedex/internal/session"
+)
+
+func commandSeed(_ context.Context, c *session.Session, args []string, _ map[string]string) error {
+	if len(args) == 0 {
+		fmt.Printf("Random seed: %d\n", c.Seed)
+		return nil
+	}
+	seed, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("Invalid command - %q is not a seed.", args[0])
+	}
+	c.Reseed(seed)
+	fmt.Printf("Random seed set to %d.\n", seed)
+	return nil
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smwalke83/pokedex/internal/session"
+)
+
+func TestCommandSeed(t *testing.T) {
+	c := &session.Session{}
+	if err := commandSeed(context.Background(), c, []string{"1234"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if c.Seed != 1234 || c.RNG == nil {
+		t.Errorf("Error - seed. Actual - %v vs Expected - 1234", c.Seed)
+	}
+	if err := commandSeed(context.Background(), c, []string{"lucky"}, nil); err == nil {
+		t.Errorf("expected an error for a seed that isn't a number")
+	}
+}
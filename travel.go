@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/smwalke83/pokedex/internal/session"
+	"github.com/smwalke83/pokedex/internal/wild"
+)
+
+func commandTravel(ctx context.Context, c *session.Session, args []string, flags map[string]string) error {
+	name, err := c.ResolveName(ctx, "location-area", args[0])
+	if err != nil {
+		return err
+	}
+	loc, err := c.Client.GetLocationArea(ctx, name)
+	if err != nil {
+		return err
+	}
+	versions := areaVersions(loc)
+	version := flags["version"]
+	if version == "" {
+		// Stay in the same game if this area has it.
+		version = c.GameVersion
+		if !slices.Contains(versions, version) && len(versions) > 0 {
+			version = versions[0]
+		}
+	} else if !slices.Contains(versions, version) {
+		return fmt.Errorf("No pokemon can be found in %s in %s. Try: %s", loc.Name, version, strings.Join(versions, ", "))
+	}
+	c.Location = loc.Name
+	c.GameVersion = version
+	c.Wild = nil
+	if version == "" {
+		fmt.Printf("You travelled to %s. There are no wild pokemon here.\n", loc.Name)
+		return nil
+	}
+	fmt.Printf("You travelled to %s (%s).\n", loc.Name, version)
+	return nil
+}
+
+func commandEncounter(ctx context.Context, c *session.Session, args []string, _ map[string]string) error {
+	if c.Location == "" {
+		return errors.New("You haven't travelled anywhere yet - use travel <area> first.")
+	}
+	method := "walk"
+	if len(args) > 0 {
+		method = args[0]
+	}
+	loc, err := c.Client.GetLocationArea(ctx, c.Location)
+	if err != nil {
+		return err
+	}
+	poke, ok, err := wild.Encounter(c.RNG, loc, c.GameVersion, method)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("You looked around, but nothing appeared.")
+		return nil
+	}
+	if c.Wild != nil {
+		fmt.Printf("The wild %s got away.\n", c.Wild.Name)
+	}
+	c.Wild = &poke
+	c.SeePokemon(ctx, poke.Name)
+	fmt.Printf("A wild %s (level %d) appeared!\n", poke.Name, poke.Level)
+	return nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+	"github.com/smwalke83/pokedex/internal/session"
+)
+
+type encounterRow struct {
+	Area     string
+	Method   string
+	MinLevel int
+	MaxLevel int
+	Chance   int
+}
+
+type versionEncounters struct {
+	Version string
+	Rows    []encounterRow
+}
+
+// groupEncounters groups a pokemon's encounters by game version, merging the
+// encounter slots for the same area and method into one row. Versions and
+// rows keep the order PokeAPI returned them in.
+func groupEncounters(encounters []pokeapi.LocationAreaEncounter) []versionEncounters {
+	groups := []versionEncounters{}
+	versionIndex := make(map[string]int)
+	rowIndex := make(map[string]int)
+	for _, area := range encounters {
+		for _, vd := range area.VersionDetails {
+			vi, ok := versionIndex[vd.Version.Name]
+			if !ok {
+				vi = len(groups)
+				versionIndex[vd.Version.Name] = vi
+				groups = append(groups, versionEncounters{Version: vd.Version.Name})
+			}
+			for _, enc := range vd.EncounterDetails {
+				method := methodLabel(enc)
+				key := vd.Version.Name + "|" + area.LocationArea.Name + "|" + method
+				ri, ok := rowIndex[key]
+				if !ok {
+					rowIndex[key] = len(groups[vi].Rows)
+					groups[vi].Rows = append(groups[vi].Rows, encounterRow{
+						Area:     area.LocationArea.Name,
+						Method:   method,
+						MinLevel: enc.MinLevel,
+						MaxLevel: enc.MaxLevel,
+						Chance:   enc.Chance,
+					})
+					continue
+				}
+				row := &groups[vi].Rows[ri]
+				row.MinLevel = min(row.MinLevel, enc.MinLevel)
+				row.MaxLevel = max(row.MaxLevel, enc.MaxLevel)
+				row.Chance += enc.Chance
+			}
+		}
+	}
+	return groups
+}
+
+func methodLabel(enc pokeapi.Encounter) string {
+	if len(enc.ConditionValues) == 0 {
+		return enc.Method.Name
+	}
+	conditions := make([]string, 0, len(enc.ConditionValues))
+	for _, cv := range enc.ConditionValues {
+		conditions = append(conditions, cv.Name)
+	}
+	return fmt.Sprintf("%s (%s)", enc.Method.Name, strings.Join(conditions, ", "))
+}
+
+func levelRange(minLevel, maxLevel int) string {
+	if minLevel == maxLevel {
+		return fmt.Sprint(minLevel)
+	}
+	return fmt.Sprintf("%d-%d", minLevel, maxLevel)
+}
+
+func commandWhere(ctx context.Context, c *session.Session, args []string, flags map[string]string) error {
+	name, err := c.ResolveName(ctx, "pokemon", args[0])
+	if err != nil {
+		return err
+	}
+	poke, err := c.Client.GetPokemon(ctx, name)
+	if err != nil {
+		return err
+	}
+	encounters, err := c.Client.GetPokemonEncounters(ctx, poke.LocationAreaEncounters)
+	if err != nil {
+		return err
+	}
+	version := flags["version"]
+	found := false
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, group := range groupEncounters(encounters) {
+		if version != "" && group.Version != version {
+			continue
+		}
+		found = true
+		fmt.Fprintf(w, "%s:\n", group.Version)
+		fmt.Fprintln(w, "  AREA\tMETHOD\tLEVELS\tCHANCE")
+		for _, row := range group.Rows {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%d%%\n", row.Area, row.Method, levelRange(row.MinLevel, row.MaxLevel), row.Chance)
+		}
+	}
+	if !found {
+		if version != "" {
+			fmt.Printf("%s can't be found in the wild in %s.\n", poke.Name, version)
+		} else {
+			fmt.Printf("%s can't be found in the wild.\n", poke.Name)
+		}
+		return nil
+	}
+	return w.Flush()
+}
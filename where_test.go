@@ -0,0 +1,84 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/smwalke83/pokedex/internal/pokeapi"
+)
+
+func encounter(method string, minLevel, maxLevel, chance int, conditions ...string) pokeapi.Encounter {
+	enc := pokeapi.Encounter{
+		MinLevel: minLevel,
+		MaxLevel: maxLevel,
+		Chance:   chance,
+		Method:   pokeapi.NamedAPIResource{Name: method},
+	}
+	for _, c := range conditions {
+		enc.ConditionValues = append(enc.ConditionValues, pokeapi.NamedAPIResource{Name: c})
+	}
+	return enc
+}
+
+func TestGroupEncounters(t *testing.T) {
+	encounters := []pokeapi.LocationAreaEncounter{
+		{
+			LocationArea: pokeapi.NamedAPIResource{Name: "viridian-forest-area"},
+			VersionDetails: []pokeapi.VersionEncounterDetail{
+				{
+					Version: pokeapi.NamedAPIResource{Name: "red"},
+					EncounterDetails: []pokeapi.Encounter{
+						encounter("walk", 3, 3, 5),
+						encounter("walk", 5, 5, 5),
+					},
+				},
+				{
+					Version: pokeapi.NamedAPIResource{Name: "yellow"},
+					EncounterDetails: []pokeapi.Encounter{
+						encounter("walk", 4, 4, 10, "time-morning"),
+					},
+				},
+			},
+		},
+		{
+			LocationArea: pokeapi.NamedAPIResource{Name: "power-plant-area"},
+			VersionDetails: []pokeapi.VersionEncounterDetail{
+				{
+					Version: pokeapi.NamedAPIResource{Name: "red"},
+					EncounterDetails: []pokeapi.Encounter{
+						encounter("walk", 20, 24, 25),
+					},
+				},
+			},
+		},
+	}
+	expected := []versionEncounters{
+		{
+			Version: "red",
+			Rows: []encounterRow{
+				{Area: "viridian-forest-area", Method: "walk", MinLevel: 3, MaxLevel: 5, Chance: 10},
+				{Area: "power-plant-area", Method: "walk", MinLevel: 20, MaxLevel: 24, Chance: 25},
+			},
+		},
+		{
+			Version: "yellow",
+			Rows: []encounterRow{
+				{Area: "viridian-forest-area", Method: "walk (time-morning)", MinLevel: 4, MaxLevel: 4, Chance: 10},
+			},
+		},
+	}
+	actual := groupEncounters(encounters)
+	if len(actual) != len(expected) {
+		t.Fatalf("Error - Group Counts Don't Match: Actual - %d vs Expected - %d", len(actual), len(expected))
+	}
+	for i := range expected {
+		if actual[i].Version != expected[i].Version || len(actual[i].Rows) != len(expected[i].Rows) {
+			t.Errorf("Error - Groups Don't Match: Actual - %+v vs Expected - %+v", actual[i], expected[i])
+			continue
+		}
+		for j := range expected[i].Rows {
+			if actual[i].Rows[j] != expected[i].Rows[j] {
+				t.Errorf("Error - Rows Don't Match: Actual - %+v vs Expected - %+v", actual[i].Rows[j], expected[i].Rows[j])
+			}
+		}
+	}
+}